@@ -0,0 +1,312 @@
+// dlq - просмотр, повторная отправка и очистка DLQ-потоков NATSBroker.
+//
+// Подключение берется из INFRASTRUCTURE__NATS__URL и INFRASTRUCTURE__NATS__TOPIC_PREFIX
+// (или флагов -url и -prefix перед командой):
+//
+//	dlq streams
+//	dlq list -group orders [-from 1] [-limit 50]
+//	dlq show -group orders -seq 12 [-type app.OrderCreated] [-descriptors app.pb]
+//	dlq replay -group orders (-seq 12,15 | -all)
+//	dlq purge -group orders (-seq 12,15 | -all)
+//	dlq retention -group orders [-max-age 168h] [-max-bytes 104857600]
+//
+// -descriptors - FileDescriptorSet (protoc --include_imports --descriptor_set_out)
+// для декодирования типов, которых нет в этой программе.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	bsgostuff_config "github.com/beavernsticks/go-stuff/config"
+	bsgostuff_infrastructure "github.com/beavernsticks/go-stuff/infrastructure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "dlq:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	cfg, err := bsgostuff_config.LoadConfig[bsgostuff_config.NATS]()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	global := flag.NewFlagSet("dlq", flag.ExitOnError)
+	global.StringVar(&cfg.URL, "url", cfg.URL, "NATS URL")
+	global.StringVar(&cfg.TopicPrefix, "prefix", cfg.TopicPrefix, "topic prefix (environment)")
+	global.Usage = func() {
+		fmt.Fprintln(global.Output(), "usage: dlq [-url URL] [-prefix PREFIX] streams|list|show|replay|purge|retention [flags]")
+		global.PrintDefaults()
+	}
+	_ = global.Parse(os.Args[1:])
+	if global.NArg() == 0 {
+		global.Usage()
+		os.Exit(2)
+	}
+
+	commands := map[string]func(context.Context, *bsgostuff_infrastructure.NATSBroker, []string) error{
+		"streams":   streams,
+		"list":      list,
+		"show":      show,
+		"replay":    replay,
+		"purge":     purge,
+		"retention": retention,
+	}
+	command, ok := commands[global.Arg(0)]
+	if !ok {
+		global.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	broker, err := bsgostuff_infrastructure.NewNATSBroker(*cfg)
+	if err != nil {
+		return err
+	}
+	defer broker.Close()
+
+	return command(ctx, broker, global.Args()[1:])
+}
+
+func streams(ctx context.Context, broker *bsgostuff_infrastructure.NATSBroker, args []string) error {
+	flags := flag.NewFlagSet("streams", flag.ExitOnError)
+	_ = flags.Parse(args)
+
+	streams, err := broker.DLQStreams(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "GROUP\tSTREAM\tMESSAGES\tBYTES\tFIRST\tLAST\tMAX AGE\tMAX BYTES")
+	for _, stream := range streams {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s\n",
+			stream.QueueGroup, stream.Stream, stream.Messages, stream.Bytes, stream.FirstSeq, stream.LastSeq,
+			limit(stream.Retention.MaxAge > 0, stream.Retention.MaxAge.String()),
+			limit(stream.Retention.MaxBytes > 0, strconv.FormatInt(stream.Retention.MaxBytes, 10)))
+	}
+	return w.Flush()
+}
+
+func list(ctx context.Context, broker *bsgostuff_infrastructure.NATSBroker, args []string) error {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	group := flags.String("group", "", "queue group (required)")
+	from := flags.Uint64("from", 0, "first sequence")
+	count := flags.Int("limit", 50, "max messages, 0 - all")
+	_ = flags.Parse(args)
+	if *group == "" {
+		return errors.New("-group is required")
+	}
+
+	letters, err := broker.DeadLetters(ctx, *group, *from, *count)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SEQ\tRECEIVED\tTOPIC\tATTEMPT\tSIZE\tERROR")
+	for _, letter := range letters {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%s\n",
+			letter.Sequence, letter.Received.Format(time.RFC3339), letter.Letter.GetOriginalTopic(),
+			letter.Letter.GetAttempt(), len(letter.Letter.GetPayload()), firstLine(letter.Letter.GetError()))
+	}
+	return w.Flush()
+}
+
+func show(ctx context.Context, broker *bsgostuff_infrastructure.NATSBroker, args []string) error {
+	flags := flag.NewFlagSet("show", flag.ExitOnError)
+	group := flags.String("group", "", "queue group (required)")
+	seq := flags.Uint64("seq", 0, "message sequence (required)")
+	typeName := flags.String("type", "", "full proto message name of the payload")
+	descriptors := flags.String("descriptors", "", "FileDescriptorSet file with the payload type")
+	_ = flags.Parse(args)
+	if *group == "" || *seq == 0 {
+		return errors.New("-group and -seq are required")
+	}
+
+	letter, err := broker.DeadLetter(ctx, *group, *seq)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Sequence:  %d\n", letter.Sequence)
+	fmt.Printf("Subject:   %s\n", letter.Subject)
+	fmt.Printf("Received:  %s\n", letter.Received.Format(time.RFC3339))
+	fmt.Printf("Topic:     %s\n", letter.Letter.GetOriginalTopic())
+	fmt.Printf("Attempt:   %d\n", letter.Letter.GetAttempt())
+	fmt.Printf("Timestamp: %s\n", letter.Letter.GetTimestamp())
+	fmt.Printf("Error:     %s\n", letter.Letter.GetError())
+
+	if *typeName == "" {
+		fmt.Printf("Payload:   %d bytes, pass -type to decode\n", len(letter.Letter.GetPayload()))
+		return nil
+	}
+
+	var resolver protoregistry.MessageTypeResolver
+	if *descriptors != "" {
+		if resolver, err = loadDescriptors(*descriptors); err != nil {
+			return err
+		}
+	}
+
+	msg, err := bsgostuff_infrastructure.DecodeDeadLetterPayload(letter.Letter, *typeName, resolver)
+	if err != nil {
+		return err
+	}
+	payload, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", Resolver: jsonResolver(resolver)}.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("payload marshal failed: %w", err)
+	}
+	fmt.Printf("Payload:   %s\n", payload)
+
+	return nil
+}
+
+func replay(ctx context.Context, broker *bsgostuff_infrastructure.NATSBroker, args []string) error {
+	group, seqs, err := selection("replay", args)
+	if err != nil {
+		return err
+	}
+
+	replayed, err := broker.ReplayDeadLetters(ctx, group, seqs...)
+	fmt.Printf("replayed %d message(s)\n", replayed)
+	return err
+}
+
+func purge(ctx context.Context, broker *bsgostuff_infrastructure.NATSBroker, args []string) error {
+	group, seqs, err := selection("purge", args)
+	if err != nil {
+		return err
+	}
+
+	purged, err := broker.PurgeDeadLetters(ctx, group, seqs...)
+	fmt.Printf("purged %d message(s)\n", purged)
+	return err
+}
+
+func retention(ctx context.Context, broker *bsgostuff_infrastructure.NATSBroker, args []string) error {
+	flags := flag.NewFlagSet("retention", flag.ExitOnError)
+	group := flags.String("group", "", "queue group (required)")
+	maxAge := flags.Duration("max-age", 0, "max message age, 0 - unlimited")
+	maxBytes := flags.Int64("max-bytes", 0, "max stream size in bytes, 0 - unlimited")
+	_ = flags.Parse(args)
+	if *group == "" {
+		return errors.New("-group is required")
+	}
+
+	return broker.SetDLQRetention(ctx, *group, bsgostuff_infrastructure.DLQRetention{MaxAge: *maxAge, MaxBytes: *maxBytes})
+}
+
+// selection разбирает группу и выбранные сообщения; все сообщения требуют явного -all
+func selection(name string, args []string) (string, []uint64, error) {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	group := flags.String("group", "", "queue group (required)")
+	list := flags.String("seq", "", "comma-separated message sequences")
+	all := flags.Bool("all", false, "select all messages")
+	_ = flags.Parse(args)
+
+	switch {
+	case *group == "":
+		return "", nil, errors.New("-group is required")
+	case *list == "" && !*all:
+		return "", nil, errors.New("either -seq or -all is required")
+	case *list != "" && *all:
+		return "", nil, errors.New("-seq and -all are mutually exclusive")
+	case *all:
+		return *group, nil, nil
+	}
+
+	seqs := make([]uint64, 0)
+	for _, part := range strings.Split(*list, ",") {
+		seq, err := strconv.ParseUint(strings.TrimSpace(part), 10, 64)
+		if err != nil || seq == 0 {
+			return "", nil, fmt.Errorf("invalid sequence %q", part)
+		}
+		seqs = append(seqs, seq)
+	}
+	return *group, seqs, nil
+}
+
+// loadDescriptors загружает типы сообщений из FileDescriptorSet
+func loadDescriptors(path string) (*protoregistry.Types, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read descriptors: %w", err)
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("parse descriptors: %w", err)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("parse descriptors: %w", err)
+	}
+
+	types := &protoregistry.Types{}
+	files.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		err = registerMessages(types, file.Messages())
+		return err == nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("register descriptors: %w", err)
+	}
+	return types, nil
+}
+
+func registerMessages(types *protoregistry.Types, messages protoreflect.MessageDescriptors) error {
+	for i := 0; i < messages.Len(); i++ {
+		message := messages.Get(i)
+		if err := types.RegisterMessage(dynamicpb.NewMessageType(message)); err != nil {
+			return err
+		}
+		if err := registerMessages(types, message.Messages()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonResolver - типы для google.protobuf.Any внутри payload
+func jsonResolver(resolver protoregistry.MessageTypeResolver) interface {
+	protoregistry.ExtensionTypeResolver
+	protoregistry.MessageTypeResolver
+} {
+	if types, ok := resolver.(*protoregistry.Types); ok {
+		return types
+	}
+	return protoregistry.GlobalTypes
+}
+
+func limit(set bool, value string) string {
+	if !set {
+		return "-"
+	}
+	return value
+}
+
+func firstLine(value string) string {
+	line, _, _ := strings.Cut(value, "\n")
+	return line
+}
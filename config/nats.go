@@ -1,6 +1,12 @@
 package bsgostuff_config
 
+import "time"
+
 type NATS struct {
 	URL         string `env:"INFRASTRUCTURE__NATS__URL"`
 	TopicPrefix string `env:"INFRASTRUCTURE__NATS__TOPIC_PREFIX"`
+
+	// Ограничения хранения DLQ-потоков; нулевые значения - без ограничений
+	DLQMaxAge   time.Duration `env:"INFRASTRUCTURE__NATS__DLQ_MAX_AGE"`
+	DLQMaxBytes int64         `env:"INFRASTRUCTURE__NATS__DLQ_MAX_BYTES"`
 }
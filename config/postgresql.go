@@ -1,5 +1,7 @@
 package bsgostuff_config
 
+import "time"
+
 type PostgreSQL struct {
 	Host             string `env:"INFRASTRUCTURE__POSTGRESQL__HOST"`
 	Port             string `env:"INFRASTRUCTURE__POSTGRESQL__PORT"`
@@ -7,4 +9,26 @@ type PostgreSQL struct {
 	Password         string `env:"INFRASTRUCTURE__POSTGRESQL__PASSWORD"`
 	DBName           string `env:"INFRASTRUCTURE__POSTGRESQL__DBNAME"`
 	ConnectionString string `env:"INFRASTRUCTURE__POSTGRESQL__CONNSTRING"`
+
+	// TLS: disable, allow, prefer, require, verify-ca, verify-full
+	SSLMode     string `env:"INFRASTRUCTURE__POSTGRESQL__SSL_MODE" env-default:"disable"`
+	SSLRootCert string `env:"INFRASTRUCTURE__POSTGRESQL__SSL_ROOT_CERT"`
+	SSLCert     string `env:"INFRASTRUCTURE__POSTGRESQL__SSL_CERT"`
+	SSLKey      string `env:"INFRASTRUCTURE__POSTGRESQL__SSL_KEY"`
+
+	// Пул соединений; нулевые значения оставляют значения pgxpool по умолчанию
+	MinConns          int32         `env:"INFRASTRUCTURE__POSTGRESQL__MIN_CONNS"`
+	MaxConns          int32         `env:"INFRASTRUCTURE__POSTGRESQL__MAX_CONNS"`
+	MaxConnLifetime   time.Duration `env:"INFRASTRUCTURE__POSTGRESQL__MAX_CONN_LIFETIME"`
+	MaxConnIdleTime   time.Duration `env:"INFRASTRUCTURE__POSTGRESQL__MAX_CONN_IDLE_TIME"`
+	HealthCheckPeriod time.Duration `env:"INFRASTRUCTURE__POSTGRESQL__HEALTH_CHECK_PERIOD"`
+
+	// Параметры сессии
+	StatementTimeout time.Duration `env:"INFRASTRUCTURE__POSTGRESQL__STATEMENT_TIMEOUT"`
+	ApplicationName  string        `env:"INFRASTRUCTURE__POSTGRESQL__APPLICATION_NAME"`
+	SearchPath       string        `env:"INFRASTRUCTURE__POSTGRESQL__SEARCH_PATH"`
+
+	// Повторы подключения при старте (задержка удваивается с каждой попыткой)
+	ConnectRetries    int           `env:"INFRASTRUCTURE__POSTGRESQL__CONNECT_RETRIES" env-default:"3"`
+	ConnectRetryDelay time.Duration `env:"INFRASTRUCTURE__POSTGRESQL__CONNECT_RETRY_DELAY" env-default:"1s"`
 }
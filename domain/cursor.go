@@ -0,0 +1,134 @@
+package bsgostuff_domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	bsgostuff_types "github.com/beavernsticks/go-stuff/types"
+)
+
+// CursorPagination - пагинация по ключу (keyset): следующая страница начинается
+// после позиции, закодированной в Cursor. В отличие от Skip/Limit не деградирует
+// на больших таблицах и не пропускает/дублирует строки при вставках.
+type CursorPagination struct {
+	// Cursor - NextCursor предыдущей страницы; не задан для первой страницы
+	Cursor bsgostuff_types.String
+	Limit  bsgostuff_types.Int
+}
+
+// CursorPageInfo - сведения о странице для запроса следующей
+type CursorPageInfo struct {
+	NextCursor bsgostuff_types.String
+	HasMore    bsgostuff_types.Bool
+}
+
+// CursorKey - выражение сортировки и значение последней строки страницы (в текстовом виде)
+type CursorKey struct {
+	Column string         `json:"c"`
+	Desc   bool           `json:"d,omitempty"`
+	Nulls  NullsOrderEnum `json:"n,omitempty"`
+	Value  *string        `json:"v"`
+}
+
+// Cursor - позиция в упорядоченной выборке: ключи активной сортировки, последний - id
+type Cursor struct {
+	Keys []CursorKey `json:"k"`
+}
+
+// Matches сообщает, что курсор построен для той же сортировки
+func (c Cursor) Matches(keys []CursorKey) bool {
+	if len(c.Keys) != len(keys) {
+		return false
+	}
+	for i := range keys {
+		if c.Keys[i].Column != keys[i].Column || c.Keys[i].Desc != keys[i].Desc || c.Keys[i].Nulls != keys[i].Nulls {
+			return false
+		}
+	}
+	return true
+}
+
+// CursorCodec кодирует курсоры в непрозрачные строки (base64url).
+// С секретом курсор подписывается HMAC-SHA256, и подделанный клиентом курсор отклоняется.
+type CursorCodec struct {
+	secret []byte
+}
+
+// NewCursorCodec создает кодек; пустой secret отключает подпись
+func NewCursorCodec(secret []byte) *CursorCodec {
+	return &CursorCodec{secret: secret}
+}
+
+// Encode кодирует курсор
+func (c *CursorCodec) Encode(cursor Cursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("cursor marshal failed: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	if len(c.secret) == 0 {
+		return encoded, nil
+	}
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(payload)), nil
+}
+
+// Decode декодирует курсор; некорректный курсор - ошибка ErrInvalidArgument
+func (c *CursorCodec) Decode(value string) (Cursor, error) {
+	var cursor Cursor
+
+	encoded, signature, signed := strings.Cut(value, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, invalidCursorError("malformed cursor")
+	}
+
+	if len(c.secret) > 0 {
+		mac, err := base64.RawURLEncoding.DecodeString(signature)
+		if !signed || err != nil || !hmac.Equal(mac, c.sign(payload)) {
+			return cursor, invalidCursorError("invalid cursor signature")
+		}
+	}
+
+	if err := json.Unmarshal(payload, &cursor); err != nil || len(cursor.Keys) == 0 {
+		return cursor, invalidCursorError("malformed cursor")
+	}
+	return cursor, nil
+}
+
+func (c *CursorCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func invalidCursorError(message string) error {
+	return NewInvalidArgumentError(FieldViolation{Field: "cursor", Code: ViolationInvalid, Message: message})
+}
+
+// PageSize - размер страницы по умолчанию и максимальный
+type PageSize struct {
+	Default int64
+	Max     int64
+}
+
+// Resolve возвращает размер страницы для запрошенного limit:
+// Default, если limit не задан, и ошибку ErrInvalidArgument, если он вне (0, Max]
+func (s PageSize) Resolve(field string, limit bsgostuff_types.Int) (int64, error) {
+	if !limit.IsSet() || limit.IsNull() || limit.GetValue() == 0 {
+		return s.Default, nil
+	}
+
+	value := limit.GetValue()
+	switch {
+	case value < 0:
+		return 0, NewInvalidArgumentError(FieldViolation{Field: field, Code: ViolationMin, Message: "must be positive"})
+	case s.Max > 0 && value > s.Max:
+		return 0, NewInvalidArgumentError(FieldViolation{Field: field, Code: ViolationMax, Message: fmt.Sprintf("must be at most %d", s.Max)})
+	}
+	return value, nil
+}
@@ -5,21 +5,21 @@ import (
 )
 
 type Entity struct {
-	ID        bsgostuff_types.ID
-	CreatedAt bsgostuff_types.Timestamp
-	UpdatedAt bsgostuff_types.Timestamp
+	ID        bsgostuff_types.ID        `db:"id"`
+	CreatedAt bsgostuff_types.Timestamp `db:"created_at"`
+	UpdatedAt bsgostuff_types.Timestamp `db:"updated_at"`
 }
 
 type DeletableEntity struct {
-	ID        bsgostuff_types.ID
-	CreatedAt bsgostuff_types.Timestamp
-	UpdatedAt bsgostuff_types.Timestamp
-	IsDeleted bsgostuff_types.Bool
+	ID        bsgostuff_types.ID        `db:"id"`
+	CreatedAt bsgostuff_types.Timestamp `db:"created_at"`
+	UpdatedAt bsgostuff_types.Timestamp `db:"updated_at"`
+	IsDeleted bsgostuff_types.Bool      `db:"is_deleted"`
 }
 
 type UnmodifiedEntity struct {
-	ID        bsgostuff_types.ID
-	CreatedAt bsgostuff_types.Timestamp
+	ID        bsgostuff_types.ID        `db:"id"`
+	CreatedAt bsgostuff_types.Timestamp `db:"created_at"`
 }
 
 func NewEntity() Entity {
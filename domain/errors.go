@@ -30,3 +30,113 @@ func IsDuplicateError(err error) bool {
 func IsInternaltError(err error) bool {
 	return errors.Is(err, ErrInternal)
 }
+
+// ResourceInfo описывает ресурс, к которому относится ошибка
+type ResourceInfo struct {
+	Type        string
+	Name        string
+	Owner       string
+	Description string
+}
+
+// Error - доменная ошибка с деталями для клиента.
+// Оборачивает одну из ошибок-сентинелов (Kind), поэтому errors.Is продолжает работать.
+type Error struct {
+	Kind       error
+	Reason     string
+	Message    string
+	Violations []FieldViolation
+	Resource   *ResourceInfo
+	Metadata   map[string]string
+	Cause      error
+}
+
+// NewError создает доменную ошибку указанного вида с сообщением для клиента
+func NewError(kind error, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// NewNotFoundError создает ошибку ErrNotFound с информацией о ресурсе
+func NewNotFoundError(resourceType, resourceName string) *Error {
+	return NewError(ErrNotFound, resourceType+" not found").WithResource(ResourceInfo{Type: resourceType, Name: resourceName})
+}
+
+// NewDuplicateError создает ошибку ErrDuplicate с информацией о ресурсе
+func NewDuplicateError(resourceType, resourceName string) *Error {
+	return NewError(ErrDuplicate, resourceType+" already exists").WithResource(ResourceInfo{Type: resourceType, Name: resourceName})
+}
+
+// NewInvalidArgumentError создает ошибку ErrInvalidArgument с нарушениями по полям
+func NewInvalidArgumentError(violations ...FieldViolation) *Error {
+	return NewError(ErrInvalidArgument, ErrInvalidArgument.Error()).WithViolations(violations...)
+}
+
+// WithReason задает машиночитаемую причину ошибки (UPPER_SNAKE_CASE)
+func (e *Error) WithReason(reason string) *Error {
+	e.Reason = reason
+	return e
+}
+
+func (e *Error) WithViolations(violations ...FieldViolation) *Error {
+	e.Violations = append(e.Violations, violations...)
+	return e
+}
+
+func (e *Error) WithResource(resource ResourceInfo) *Error {
+	e.Resource = &resource
+	return e
+}
+
+func (e *Error) WithMetadata(key, value string) *Error {
+	if e.Metadata == nil {
+		e.Metadata = make(map[string]string)
+	}
+	e.Metadata[key] = value
+	return e
+}
+
+// WithCause сохраняет исходную ошибку; она не передается клиенту
+func (e *Error) WithCause(cause error) *Error {
+	e.Cause = cause
+	return e
+}
+
+func (e *Error) Error() string {
+	kind := ErrInternal
+	if e.Kind != nil {
+		kind = e.Kind
+	}
+
+	message := kind.Error()
+	if e.Message != "" && e.Message != message {
+		message += ": " + e.Message
+	}
+	if e.Cause != nil {
+		message += ": " + e.Cause.Error()
+	}
+	return message
+}
+
+func (e *Error) Unwrap() []error {
+	errs := make([]error, 0, 2)
+	if e.Kind != nil {
+		errs = append(errs, e.Kind)
+	}
+	if e.Cause != nil {
+		errs = append(errs, e.Cause)
+	}
+	return errs
+}
+
+// AsError извлекает доменную ошибку из цепочки.
+// ValidationError преобразуется в Error вида ErrInvalidArgument.
+func AsError(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	if validationErr, ok := AsValidationError(err); ok {
+		return NewInvalidArgumentError(validationErr.Violations...), true
+	}
+	return nil, false
+}
@@ -0,0 +1,224 @@
+package bsgostuff_domain
+
+import (
+	"fmt"
+	"slices"
+
+	bsgostuff_types "github.com/beavernsticks/go-stuff/types"
+)
+
+type FilterOperatorEnum string
+
+const (
+	FilterOperatorEnumUnknown   FilterOperatorEnum = ""
+	FilterOperatorEnumEq        FilterOperatorEnum = "EQ"
+	FilterOperatorEnumNe        FilterOperatorEnum = "NE"
+	FilterOperatorEnumIn        FilterOperatorEnum = "IN"
+	FilterOperatorEnumNotIn     FilterOperatorEnum = "NOT_IN"
+	FilterOperatorEnumLt        FilterOperatorEnum = "LT"
+	FilterOperatorEnumLte       FilterOperatorEnum = "LTE"
+	FilterOperatorEnumGt        FilterOperatorEnum = "GT"
+	FilterOperatorEnumGte       FilterOperatorEnum = "GTE"
+	FilterOperatorEnumBetween   FilterOperatorEnum = "BETWEEN"
+	FilterOperatorEnumLike      FilterOperatorEnum = "LIKE"
+	FilterOperatorEnumIlike     FilterOperatorEnum = "ILIKE"
+	FilterOperatorEnumIsNull    FilterOperatorEnum = "IS_NULL"
+	FilterOperatorEnumIsNotNull FilterOperatorEnum = "IS_NOT_NULL"
+)
+
+func (s FilterOperatorEnum) Valid() bool {
+	switch s {
+	case FilterOperatorEnumEq, FilterOperatorEnumNe, FilterOperatorEnumIn, FilterOperatorEnumNotIn,
+		FilterOperatorEnumLt, FilterOperatorEnumLte, FilterOperatorEnumGt, FilterOperatorEnumGte, FilterOperatorEnumBetween,
+		FilterOperatorEnumLike, FilterOperatorEnumIlike, FilterOperatorEnumIsNull, FilterOperatorEnumIsNotNull:
+		return true
+	default:
+		return false
+	}
+}
+
+// MaxFilterDepth ограничивает вложенность and/or/not
+const MaxFilterDepth = 8
+
+// Filter - выражение фильтрации: условие (Field, Operator, Values)
+// либо одна из комбинаций And, Or, Not. Пустой Filter не фильтрует.
+type Filter struct {
+	Field    bsgostuff_types.String
+	Operator bsgostuff_types.Enum[FilterOperatorEnum]
+	// Values - значения в исходном виде (строки, числа, bool);
+	// приводятся к типу поля из FilterFields
+	Values []any
+
+	And []Filter
+	Or  []Filter
+	Not *Filter
+}
+
+// Where создает условие
+func Where(field string, operator FilterOperatorEnum, values ...any) Filter {
+	return Filter{Field: bsgostuff_types.NewString(field), Operator: bsgostuff_types.NewEnum(operator), Values: values}
+}
+
+// And объединяет фильтры через AND
+func And(filters ...Filter) Filter {
+	return Filter{And: filters}
+}
+
+// Or объединяет фильтры через OR
+func Or(filters ...Filter) Filter {
+	return Filter{Or: filters}
+}
+
+// Not инвертирует фильтр
+func Not(filter Filter) Filter {
+	return Filter{Not: &filter}
+}
+
+// IsEmpty сообщает, что фильтр не задает условий
+func (f Filter) IsEmpty() bool {
+	return f.Field.GetValue() == "" && !f.Operator.IsSet() && len(f.Values) == 0 &&
+		len(f.And) == 0 && len(f.Or) == 0 && f.Not == nil
+}
+
+// FilterValue - типизированное значение поля (Settable из bsgostuff_types)
+type FilterValue interface {
+	Set(value any) error
+}
+
+// FilterField описывает поле, доступное для фильтрации
+type FilterField struct {
+	// Expression - SQL-выражение поля
+	Expression string
+	// NewValue создает значение для приведения и передачи в запрос
+	NewValue func() FilterValue
+	// Operators - допустимые операторы; по умолчанию все,
+	// кроме LIKE/ILIKE для нестроковых полей
+	Operators []FilterOperatorEnum
+}
+
+// NewFilterField описывает поле с типом значения T, например
+// NewFilterField[bsgostuff_types.Int]("rank")
+func NewFilterField[T any, PT interface {
+	*T
+	FilterValue
+}](expression string, operators ...FilterOperatorEnum) FilterField {
+	return FilterField{
+		Expression: expression,
+		NewValue:   func() FilterValue { return PT(new(T)) },
+		Operators:  operators,
+	}
+}
+
+// Allows сообщает, допустим ли оператор для поля
+func (f FilterField) Allows(operator FilterOperatorEnum) bool {
+	if len(f.Operators) > 0 {
+		return slices.Contains(f.Operators, operator)
+	}
+	if operator == FilterOperatorEnumLike || operator == FilterOperatorEnumIlike {
+		_, ok := f.NewValue().(*bsgostuff_types.String)
+		return ok
+	}
+	return operator.Valid()
+}
+
+// FilterFields - белый список фильтрации: публичное имя поля -> описание
+type FilterFields map[string]FilterField
+
+// Validate проверяет фильтр по белому списку: структуру, поля, операторы,
+// число и типы значений. Все нарушения возвращаются одной ошибкой ErrInvalidArgument.
+func (f Filter) Validate(fields FilterFields) error {
+	v := NewValidator()
+	f.validate(v, "filter", fields, 1)
+	return v.Err()
+}
+
+func (f Filter) validate(v *Validator, path string, fields FilterFields, depth int) {
+	if depth > MaxFilterDepth {
+		v.Add(path, ViolationMax, fmt.Sprintf("nesting must be at most %d levels", MaxFilterDepth))
+		return
+	}
+
+	kinds := 0
+	for _, present := range []bool{f.Field.GetValue() != "" || f.Operator.IsSet(), len(f.And) > 0, len(f.Or) > 0, f.Not != nil} {
+		if present {
+			kinds++
+		}
+	}
+	if kinds > 1 {
+		v.Add(path, ViolationInvalid, "must be exactly one of condition, and, or, not")
+		return
+	}
+
+	for i, filter := range f.And {
+		filter.validate(v, fmt.Sprintf("%s.and[%d]", path, i), fields, depth+1)
+	}
+	for i, filter := range f.Or {
+		filter.validate(v, fmt.Sprintf("%s.or[%d]", path, i), fields, depth+1)
+	}
+	if f.Not != nil {
+		f.Not.validate(v, path+".not", fields, depth+1)
+	}
+	if kinds == 0 && len(f.Values) > 0 {
+		v.Add(path+".field", ViolationRequired, "is required")
+	}
+	if kinds == 0 || f.Field.GetValue() == "" && !f.Operator.IsSet() {
+		return
+	}
+
+	field, ok := fields[f.Field.GetValue()]
+	if !ok {
+		v.Add(path+".field", ViolationOneOf, fmt.Sprintf("unsupported filter field %q", f.Field.GetValue()))
+		return
+	}
+	operator := f.Operator.GetValue()
+	if !operator.Valid() {
+		v.Add(path+".operator", ViolationEnum, fmt.Sprintf("unsupported operator %q", operator))
+		return
+	}
+	if !field.Allows(operator) {
+		v.Add(path+".operator", ViolationOneOf, fmt.Sprintf("operator %s is not allowed for %q", operator, f.Field.GetValue()))
+		return
+	}
+
+	minValues, maxValues := operator.arity()
+	if len(f.Values) < minValues || maxValues >= 0 && len(f.Values) > maxValues {
+		v.Add(path+".values", ViolationInvalid, operator.arityMessage())
+		return
+	}
+	for i, raw := range f.Values {
+		if raw == nil {
+			v.Add(fmt.Sprintf("%s.values[%d]", path, i), ViolationNotNull, "must not be null, use IS_NULL")
+			continue
+		}
+		if err := field.NewValue().Set(raw); err != nil {
+			v.Add(fmt.Sprintf("%s.values[%d]", path, i), ViolationInvalid, err.Error())
+		}
+	}
+}
+
+// arity возвращает допустимое число значений оператора (max < 0 - без ограничения)
+func (s FilterOperatorEnum) arity() (int, int) {
+	switch s {
+	case FilterOperatorEnumIsNull, FilterOperatorEnumIsNotNull:
+		return 0, 0
+	case FilterOperatorEnumIn, FilterOperatorEnumNotIn:
+		return 1, -1
+	case FilterOperatorEnumBetween:
+		return 2, 2
+	default:
+		return 1, 1
+	}
+}
+
+func (s FilterOperatorEnum) arityMessage() string {
+	switch s {
+	case FilterOperatorEnumIsNull, FilterOperatorEnumIsNotNull:
+		return "must be empty"
+	case FilterOperatorEnumIn, FilterOperatorEnumNotIn:
+		return "must contain at least 1 value"
+	case FilterOperatorEnumBetween:
+		return "must contain exactly 2 values"
+	default:
+		return "must contain exactly 1 value"
+	}
+}
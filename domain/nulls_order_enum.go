@@ -0,0 +1,18 @@
+package bsgostuff_domain
+
+type NullsOrderEnum string
+
+const (
+	NullsOrderEnumUnknown NullsOrderEnum = ""
+	NullsOrderEnumFirst   NullsOrderEnum = "FIRST"
+	NullsOrderEnumLast    NullsOrderEnum = "LAST"
+)
+
+func (s NullsOrderEnum) Valid() bool {
+	switch s {
+	case NullsOrderEnumFirst, NullsOrderEnumLast:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,44 @@
+package bsgostuff_domain
+
+import bsgostuff_types "github.com/beavernsticks/go-stuff/types"
+
+// Page - страница результатов Skip/Limit с общим числом элементов
+type Page[T any] struct {
+	Items   []T
+	Total   bsgostuff_types.Int
+	Skip    bsgostuff_types.Int
+	Limit   bsgostuff_types.Int
+	HasNext bsgostuff_types.Bool
+}
+
+// NewPage собирает страницу для запроса pagination; HasNext вычисляется по total
+func NewPage[T any](items []T, total int64, pagination Pagination) Page[T] {
+	skip := pagination.Skip.GetValue()
+	if items == nil {
+		items = make([]T, 0)
+	}
+
+	return Page[T]{
+		Items:   items,
+		Total:   bsgostuff_types.NewInt(total),
+		Skip:    bsgostuff_types.NewInt(skip),
+		Limit:   pagination.Limit,
+		HasNext: bsgostuff_types.NewBool(skip+int64(len(items)) < total),
+	}
+}
+
+// MapPage преобразует элементы страницы, сохраняя ее параметры
+func MapPage[T, R any](page Page[T], fn func(T) R) Page[R] {
+	items := make([]R, len(page.Items))
+	for i, item := range page.Items {
+		items[i] = fn(item)
+	}
+
+	return Page[R]{
+		Items:   items,
+		Total:   page.Total,
+		Skip:    page.Skip,
+		Limit:   page.Limit,
+		HasNext: page.HasNext,
+	}
+}
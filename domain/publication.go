@@ -0,0 +1,121 @@
+package bsgostuff_domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bsgostuff_types "github.com/beavernsticks/go-stuff/types"
+)
+
+// ReasonPublicationTransition - причина ошибки недопустимого перехода статуса публикации
+const ReasonPublicationTransition = "PUBLICATION_TRANSITION_NOT_ALLOWED"
+
+// Publication - состояние публикации; встраивается в сущность вместе с Entity.
+// PublishAt и UnpublishAt - запланированные публикация и снятие с публикации.
+type Publication struct {
+	Status      bsgostuff_types.Enum[PublicationStatusEnum] `db:"status"`
+	PublishAt   bsgostuff_types.Timestamp                   `db:"publish_at"`
+	UnpublishAt bsgostuff_types.Timestamp                   `db:"unpublish_at"`
+	PublishedAt bsgostuff_types.Timestamp                   `db:"published_at"`
+}
+
+func NewPublication() Publication {
+	return Publication{Status: bsgostuff_types.NewEnum(PublicationStatusEnumDraft)}
+}
+
+// PublicationChange - переход статуса публикации: запись истории и содержимое события
+type PublicationChange struct {
+	EntityID bsgostuff_types.ID                          `db:"entity_id"`
+	From     bsgostuff_types.Enum[PublicationStatusEnum] `db:"from_status"`
+	To       bsgostuff_types.Enum[PublicationStatusEnum] `db:"to_status"`
+	// Actor - кто выполнил переход; не задан для переходов по расписанию
+	Actor     bsgostuff_types.String    `db:"actor"`
+	Reason    bsgostuff_types.String    `db:"reason"`
+	Scheduled bsgostuff_types.Bool      `db:"scheduled"`
+	At        bsgostuff_types.Timestamp `db:"created_at"`
+}
+
+// PublicationGuard проверяет, можно ли выполнить переход (например, заполнены ли обязательные поля)
+type PublicationGuard func(ctx context.Context, change PublicationChange) error
+
+// PublicationWorkflow - конечный автомат статусов публикации: допустимые переходы и их guard-ы.
+// По умолчанию разрешены DRAFT -> PUBLISHED, PUBLISHED -> UNPUBLISHED,
+// UNPUBLISHED -> PUBLISHED и UNPUBLISHED -> DRAFT.
+type PublicationWorkflow struct {
+	transitions map[PublicationStatusEnum]map[PublicationStatusEnum][]PublicationGuard
+}
+
+func NewPublicationWorkflow() *PublicationWorkflow {
+	w := &PublicationWorkflow{transitions: make(map[PublicationStatusEnum]map[PublicationStatusEnum][]PublicationGuard)}
+	w.Allow(PublicationStatusEnumDraft, PublicationStatusEnumPublished)
+	w.Allow(PublicationStatusEnumPublished, PublicationStatusEnumUnpublished)
+	w.Allow(PublicationStatusEnumUnpublished, PublicationStatusEnumPublished)
+	w.Allow(PublicationStatusEnumUnpublished, PublicationStatusEnumDraft)
+	return w
+}
+
+// Allow разрешает переход from -> to и добавляет к нему guard-ы
+func (w *PublicationWorkflow) Allow(from, to PublicationStatusEnum, guards ...PublicationGuard) *PublicationWorkflow {
+	if w.transitions[from] == nil {
+		w.transitions[from] = make(map[PublicationStatusEnum][]PublicationGuard)
+	}
+	w.transitions[from][to] = append(w.transitions[from][to], guards...)
+	return w
+}
+
+// Forbid запрещает переход from -> to
+func (w *PublicationWorkflow) Forbid(from, to PublicationStatusEnum) *PublicationWorkflow {
+	delete(w.transitions[from], to)
+	return w
+}
+
+// Can сообщает, разрешен ли переход from -> to (без учета guard-ов)
+func (w *PublicationWorkflow) Can(from, to PublicationStatusEnum) bool {
+	_, ok := w.transitions[from][to]
+	return ok
+}
+
+// Check проверяет переход: недопустимый переход - ошибка ErrInvalidArgument
+// с причиной ReasonPublicationTransition, ошибки guard-ов возвращаются как есть
+func (w *PublicationWorkflow) Check(ctx context.Context, change PublicationChange) error {
+	from, to := change.From.GetValue(), change.To.GetValue()
+
+	guards, ok := w.transitions[from][to]
+	if !ok {
+		return NewError(ErrInvalidArgument, fmt.Sprintf("publication transition from %q to %q is not allowed", from, to)).
+			WithReason(ReasonPublicationTransition)
+	}
+
+	for _, guard := range guards {
+		if err := guard(ctx, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PublicationSchedule - расписание публикации: незаданное поле не меняется, null - отменяет план
+type PublicationSchedule struct {
+	PublishAt   bsgostuff_types.Timestamp
+	UnpublishAt bsgostuff_types.Timestamp
+}
+
+// Validate требует, чтобы запланированное время было в будущем,
+// а снятие с публикации - позже публикации
+func (s PublicationSchedule) Validate() error {
+	v := NewValidator()
+	now := time.Now()
+
+	publishAt, unpublishAt := s.PublishAt.GetValue(), s.UnpublishAt.GetValue()
+	if present(s.PublishAt) && !publishAt.After(now) {
+		v.Add("publish_at", ViolationMin, "must be in the future")
+	}
+	if present(s.UnpublishAt) && !unpublishAt.After(now) {
+		v.Add("unpublish_at", ViolationMin, "must be in the future")
+	}
+	if present(s.PublishAt) && present(s.UnpublishAt) && !unpublishAt.After(publishAt) {
+		v.Add("unpublish_at", ViolationMin, "must be after publish_at")
+	}
+	return v.Err()
+}
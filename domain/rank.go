@@ -0,0 +1,146 @@
+package bsgostuff_domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	bsgostuff_types "github.com/beavernsticks/go-stuff/types"
+)
+
+// Ранги - строки из цифр base62, упорядоченные побайтово (в PostgreSQL - COLLATE "C").
+// Между любыми двумя рангами всегда есть третий, поэтому перемещение меняет
+// только ранг перемещаемого элемента. Ранг не оканчивается нулевой цифрой.
+const (
+	rankDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	rankBase   = len(rankDigits)
+
+	// MaxRankLength - длина ранга, после которой область упорядочивания перебалансируется
+	MaxRankLength = 24
+)
+
+// RankBetween возвращает ранг строго между prev и next.
+// Пустой prev означает начало списка, пустой next - конец.
+func RankBetween(prev, next string) (string, error) {
+	if err := validateRank(prev); err != nil {
+		return "", err
+	}
+	if err := validateRank(next); err != nil {
+		return "", err
+	}
+	if next != "" && prev >= next {
+		return "", fmt.Errorf("%w: rank %q must be less than %q", ErrInvalidArgument, prev, next)
+	}
+	return rankMidpoint(prev, next), nil
+}
+
+// RankSequence возвращает n равномерно распределенных возрастающих рангов (для перебалансировки)
+func RankSequence(n int) []string {
+	// Ширина выбирается так, чтобы между соседними рангами оставалось не меньше base^2 значений
+	width, space := 1, int64(rankBase)
+	for space/int64(n+1) < int64(rankBase*rankBase) {
+		width++
+		space *= int64(rankBase)
+	}
+	step := space / int64(n+1)
+
+	ranks := make([]string, n)
+	for i := range ranks {
+		value := step * int64(i+1)
+		digits := make([]byte, width)
+		for j := width - 1; j >= 0; j-- {
+			digits[j] = rankDigits[value%int64(rankBase)]
+			value /= int64(rankBase)
+		}
+		ranks[i] = strings.TrimRight(string(digits), rankDigits[:1])
+	}
+	return ranks
+}
+
+// rankMidpoint - середина между prev и next (prev < next, пустой next - бесконечность)
+func rankMidpoint(prev, next string) string {
+	// Общий префикс (недостающие цифры prev считаются нулевыми)
+	n := 0
+	for n < len(next) && rankDigit(prev, n) == strings.IndexByte(rankDigits, next[n]) {
+		n++
+	}
+	if n > 0 {
+		return next[:n] + rankMidpoint(suffix(prev, n), next[n:])
+	}
+
+	low := rankDigit(prev, 0)
+	high := rankBase
+	if next != "" {
+		high = strings.IndexByte(rankDigits, next[0])
+	}
+	if high-low > 1 {
+		return string(rankDigits[(low+high)/2])
+	}
+
+	// Соседние цифры: укороченный next уже больше prev, иначе продолжаем после prev[0]
+	if len(next) > 1 {
+		return next[:1]
+	}
+	return string(rankDigits[low]) + rankMidpoint(suffix(prev, 1), "")
+}
+
+func rankDigit(rank string, i int) int {
+	if i >= len(rank) {
+		return 0
+	}
+	return strings.IndexByte(rankDigits, rank[i])
+}
+
+func suffix(rank string, n int) string {
+	if n >= len(rank) {
+		return ""
+	}
+	return rank[n:]
+}
+
+func validateRank(rank string) error {
+	for i := 0; i < len(rank); i++ {
+		if strings.IndexByte(rankDigits, rank[i]) < 0 {
+			return fmt.Errorf("%w: invalid rank %q", ErrInvalidArgument, rank)
+		}
+	}
+	if strings.HasSuffix(rank, rankDigits[:1]) {
+		return fmt.Errorf("%w: rank %q must not end with %q", ErrInvalidArgument, rank, rankDigits[:1])
+	}
+	return nil
+}
+
+// Move описывает перемещение элемента: на шаг или к краю (Direction)
+// либо сразу после/перед другим элементом той же области (After/Before)
+type Move struct {
+	Direction bsgostuff_types.Enum[MoveDirectionEnum]
+	After     bsgostuff_types.ID
+	Before    bsgostuff_types.ID
+}
+
+// Validate требует ровно один из способов перемещения
+func (m Move) Validate() error {
+	v := NewValidator()
+	Check(v, "direction", m.Direction, ValidEnum[MoveDirectionEnum])
+
+	count := 0
+	for _, set := range []bool{present(m.Direction), present(m.After), present(m.Before)} {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		v.Add("", ViolationInvalid, "exactly one of direction, after, before is required")
+	}
+	return v.Err()
+}
+
+// Orderer упорядочивает элементы внутри области (например, дочерние элементы родителя)
+type Orderer interface {
+	// Append возвращает ранг для нового элемента в конце области
+	Append(ctx context.Context, scope bsgostuff_types.ID) (string, error)
+	// Move перемещает элемент и возвращает его новый ранг
+	Move(ctx context.Context, scope, id bsgostuff_types.ID, move Move) (string, error)
+	// Rebalance равномерно перераспределяет ранги области, сохраняя порядок
+	Rebalance(ctx context.Context, scope bsgostuff_types.ID) error
+}
@@ -2,7 +2,15 @@ package bsgostuff_domain
 
 import bsgostuff_types "github.com/beavernsticks/go-stuff/types"
 
+// Sort - ключ сортировки; запрос может содержать несколько ключей ([]Sort),
+// которые применяются по порядку
 type Sort struct {
 	Field      bsgostuff_types.String
 	IsReversed bsgostuff_types.Bool
+	// Nulls - положение NULL; по умолчанию как в PostgreSQL (LAST для ASC, FIRST для DESC)
+	Nulls bsgostuff_types.Enum[NullsOrderEnum]
 }
+
+// SortFields - белый список сортировки: публичное имя поля -> SQL-выражение.
+// В ORDER BY попадают только выражения из списка, имя поля от клиента - никогда.
+type SortFields map[string]string
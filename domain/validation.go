@@ -0,0 +1,276 @@
+package bsgostuff_domain
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Коды нарушений валидации
+const (
+	ViolationRequired  = "REQUIRED"
+	ViolationNotNull   = "NOT_NULL"
+	ViolationNotEmpty  = "NOT_EMPTY"
+	ViolationMinLength = "MIN_LENGTH"
+	ViolationMaxLength = "MAX_LENGTH"
+	ViolationPattern   = "PATTERN"
+	ViolationMin       = "MIN"
+	ViolationMax       = "MAX"
+	ViolationOneOf     = "ONE_OF"
+	ViolationEnum      = "INVALID_ENUM"
+	ViolationMinItems  = "MIN_ITEMS"
+	ViolationMaxItems  = "MAX_ITEMS"
+	ViolationInvalid   = "INVALID"
+)
+
+// FieldViolation описывает нарушение правила для конкретного поля
+type FieldViolation struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+// ValidationError содержит все нарушения и оборачивает ErrInvalidArgument
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		parts = append(parts, violation.Field+": "+violation.Message)
+	}
+	return ErrInvalidArgument.Error() + ": " + strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidArgument
+}
+
+// AsValidationError извлекает ValidationError из цепочки ошибок
+func AsValidationError(err error) (*ValidationError, bool) {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr, true
+	}
+	return nil, false
+}
+
+// Nullable - значение с трехзначной логикой (реализуется всеми типами из bsgostuff_types)
+type Nullable[T any] interface {
+	GetValue() T
+	IsSet() bool
+	IsNull() bool
+}
+
+// Validatable реализуется структурами, умеющими валидировать себя
+type Validatable interface {
+	Validate() error
+}
+
+// Rule проверяет значение и возвращает нарушение или nil.
+// Поле Field заполняется валидатором.
+type Rule[T any] func(value Nullable[T]) *FieldViolation
+
+// Validator накапливает нарушения по полям
+type Validator struct {
+	violations []FieldViolation
+}
+
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+// Check применяет правила к полю; для поля фиксируется только первое нарушение
+func Check[T any](v *Validator, field string, value Nullable[T], rules ...Rule[T]) *Validator {
+	for _, rule := range rules {
+		if violation := rule(value); violation != nil {
+			violation.Field = field
+			v.violations = append(v.violations, *violation)
+			break
+		}
+	}
+	return v
+}
+
+// Nested валидирует вложенную структуру, добавляя префикс к именам полей
+func (v *Validator) Nested(prefix string, value Validatable) *Validator {
+	err := value.Validate()
+	if err == nil {
+		return v
+	}
+
+	validationErr, ok := AsValidationError(err)
+	if !ok {
+		return v.Add(prefix, ViolationInvalid, err.Error())
+	}
+	for _, violation := range validationErr.Violations {
+		violation.Field = joinFieldPath(prefix, violation.Field)
+		v.violations = append(v.violations, violation)
+	}
+	return v
+}
+
+// Add добавляет произвольное нарушение (для проверок, затрагивающих несколько полей)
+func (v *Validator) Add(field, code, message string) *Validator {
+	v.violations = append(v.violations, FieldViolation{Field: field, Code: code, Message: message})
+	return v
+}
+
+// Valid сообщает, что нарушений нет
+func (v *Validator) Valid() bool {
+	return len(v.violations) == 0
+}
+
+// Err возвращает *ValidationError или nil, если нарушений нет
+func (v *Validator) Err() error {
+	if v.Valid() {
+		return nil
+	}
+	return &ValidationError{Violations: slices.Clone(v.violations)}
+}
+
+func joinFieldPath(prefix, field string) string {
+	switch {
+	case prefix == "":
+		return field
+	case field == "":
+		return prefix
+	default:
+		return prefix + "." + field
+	}
+}
+
+// present сообщает, что значение передано и не равно null
+func present[T any](value Nullable[T]) bool {
+	return value.IsSet() && !value.IsNull()
+}
+
+// Required требует, чтобы значение было передано и не было null
+func Required[T any](value Nullable[T]) *FieldViolation {
+	if !present(value) {
+		return &FieldViolation{Code: ViolationRequired, Message: "is required"}
+	}
+	return nil
+}
+
+// NotNull запрещает явный null, но допускает отсутствие значения (частичное обновление)
+func NotNull[T any](value Nullable[T]) *FieldViolation {
+	if value.IsSet() && value.IsNull() {
+		return &FieldViolation{Code: ViolationNotNull, Message: "must not be null"}
+	}
+	return nil
+}
+
+// Остальные правила проверяют только переданные и не null значения
+
+// NotEmpty запрещает нулевое значение типа (пустая строка, 0, false)
+func NotEmpty[T comparable](value Nullable[T]) *FieldViolation {
+	var zero T
+	if present(value) && value.GetValue() == zero {
+		return &FieldViolation{Code: ViolationNotEmpty, Message: "must not be empty"}
+	}
+	return nil
+}
+
+// MinLength ограничивает минимальную длину строки в символах
+func MinLength(n int) Rule[string] {
+	return func(value Nullable[string]) *FieldViolation {
+		if present(value) && utf8.RuneCountInString(value.GetValue()) < n {
+			return &FieldViolation{Code: ViolationMinLength, Message: fmt.Sprintf("must be at least %d characters", n)}
+		}
+		return nil
+	}
+}
+
+// MaxLength ограничивает максимальную длину строки в символах
+func MaxLength(n int) Rule[string] {
+	return func(value Nullable[string]) *FieldViolation {
+		if present(value) && utf8.RuneCountInString(value.GetValue()) > n {
+			return &FieldViolation{Code: ViolationMaxLength, Message: fmt.Sprintf("must be at most %d characters", n)}
+		}
+		return nil
+	}
+}
+
+// Pattern требует соответствия строки регулярному выражению
+func Pattern(re *regexp.Regexp) Rule[string] {
+	return func(value Nullable[string]) *FieldViolation {
+		if present(value) && !re.MatchString(value.GetValue()) {
+			return &FieldViolation{Code: ViolationPattern, Message: fmt.Sprintf("must match %s", re)}
+		}
+		return nil
+	}
+}
+
+// Min ограничивает минимальное значение, например Min[int64](1) для types.Int
+func Min[T cmp.Ordered](n T) Rule[T] {
+	return func(value Nullable[T]) *FieldViolation {
+		if present(value) && value.GetValue() < n {
+			return &FieldViolation{Code: ViolationMin, Message: fmt.Sprintf("must be at least %v", n)}
+		}
+		return nil
+	}
+}
+
+// Max ограничивает максимальное значение, например Max[int64](100) для types.Int
+func Max[T cmp.Ordered](n T) Rule[T] {
+	return func(value Nullable[T]) *FieldViolation {
+		if present(value) && value.GetValue() > n {
+			return &FieldViolation{Code: ViolationMax, Message: fmt.Sprintf("must be at most %v", n)}
+		}
+		return nil
+	}
+}
+
+// OneOf допускает только перечисленные значения
+func OneOf[T comparable](allowed ...T) Rule[T] {
+	return func(value Nullable[T]) *FieldViolation {
+		if present(value) && !slices.Contains(allowed, value.GetValue()) {
+			return &FieldViolation{Code: ViolationOneOf, Message: fmt.Sprintf("must be one of %v", allowed)}
+		}
+		return nil
+	}
+}
+
+// ValidEnum вызывает Valid() у значения доменного enum
+func ValidEnum[T interface{ Valid() bool }](value Nullable[T]) *FieldViolation {
+	if present(value) && !value.GetValue().Valid() {
+		return &FieldViolation{Code: ViolationEnum, Message: fmt.Sprintf("has invalid value %v", value.GetValue())}
+	}
+	return nil
+}
+
+// MinItems ограничивает минимальное количество элементов, например MinItems[string](1) для types.Strings
+func MinItems[T any](n int) Rule[[]T] {
+	return func(value Nullable[[]T]) *FieldViolation {
+		if present(value) && len(value.GetValue()) < n {
+			return &FieldViolation{Code: ViolationMinItems, Message: fmt.Sprintf("must contain at least %d items", n)}
+		}
+		return nil
+	}
+}
+
+// MaxItems ограничивает максимальное количество элементов
+func MaxItems[T any](n int) Rule[[]T] {
+	return func(value Nullable[[]T]) *FieldViolation {
+		if present(value) && len(value.GetValue()) > n {
+			return &FieldViolation{Code: ViolationMaxItems, Message: fmt.Sprintf("must contain at most %d items", n)}
+		}
+		return nil
+	}
+}
+
+// Custom создает правило из произвольного предиката
+func Custom[T any](code, message string, valid func(T) bool) Rule[T] {
+	return func(value Nullable[T]) *FieldViolation {
+		if present(value) && !valid(value.GetValue()) {
+			return &FieldViolation{Code: code, Message: message}
+		}
+		return nil
+	}
+}
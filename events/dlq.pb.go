@@ -28,6 +28,7 @@ type DeadLetter struct {
 	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
 	Attempt       int32                  `protobuf:"varint,4,opt,name=attempt,proto3" json:"attempt,omitempty"`
 	Timestamp     string                 `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ContentType   string                 `protobuf:"bytes,6,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -97,18 +98,26 @@ func (x *DeadLetter) GetTimestamp() string {
 	return ""
 }
 
+func (x *DeadLetter) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
 var File_events_dlq_proto protoreflect.FileDescriptor
 
 const file_events_dlq_proto_rawDesc = "" +
 	"\n" +
-	"\x10events/dlq.proto\x12\x10bsgostuff_events\"\x9b\x01\n" +
+	"\x10events/dlq.proto\x12\x10bsgostuff_events\"\xbe\x01\n" +
 	"\n" +
 	"DeadLetter\x12%\n" +
 	"\x0eoriginal_topic\x18\x01 \x01(\tR\roriginalTopic\x12\x18\n" +
 	"\apayload\x18\x02 \x01(\fR\apayload\x12\x14\n" +
 	"\x05error\x18\x03 \x01(\tR\x05error\x12\x18\n" +
 	"\aattempt\x18\x04 \x01(\x05R\aattempt\x12\x1c\n" +
-	"\ttimestamp\x18\x05 \x01(\tR\ttimestampB;Z9github.com/beavernsticks/go-stuff/events;bsgostuff_eventsb\x06proto3"
+	"\ttimestamp\x18\x05 \x01(\tR\ttimestamp\x12!\n" +
+	"\fcontent_type\x18\x06 \x01(\tR\vcontentTypeB;Z9github.com/beavernsticks/go-stuff/events;bsgostuff_eventsb\x06proto3"
 
 var (
 	file_events_dlq_proto_rawDescOnce sync.Once
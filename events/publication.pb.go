@@ -0,0 +1,186 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: events/publication.proto
+
+package bsgostuff_events
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PublicationChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resource      string                 `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	EntityId      string                 `protobuf:"bytes,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	FromStatus    string                 `protobuf:"bytes,3,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	ToStatus      string                 `protobuf:"bytes,4,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Scheduled     bool                   `protobuf:"varint,7,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicationChanged) Reset() {
+	*x = PublicationChanged{}
+	mi := &file_events_publication_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicationChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicationChanged) ProtoMessage() {}
+
+func (x *PublicationChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_events_publication_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicationChanged.ProtoReflect.Descriptor instead.
+func (*PublicationChanged) Descriptor() ([]byte, []int) {
+	return file_events_publication_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PublicationChanged) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *PublicationChanged) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *PublicationChanged) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *PublicationChanged) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *PublicationChanged) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *PublicationChanged) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PublicationChanged) GetScheduled() bool {
+	if x != nil {
+		return x.Scheduled
+	}
+	return false
+}
+
+func (x *PublicationChanged) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+var File_events_publication_proto protoreflect.FileDescriptor
+
+const file_events_publication_proto_rawDesc = "" +
+	"\n" +
+	"\x18events/publication.proto\x12\x10bsgostuff_events\"\xf5\x01\n" +
+	"\x12PublicationChanged\x12\x1a\n" +
+	"\bresource\x18\x01 \x01(\tR\bresource\x12\x1b\n" +
+	"\tentity_id\x18\x02 \x01(\tR\bentityId\x12\x1f\n" +
+	"\vfrom_status\x18\x03 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\x04 \x01(\tR\btoStatus\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x1c\n" +
+	"\tscheduled\x18\a \x01(\bR\tscheduled\x12\x1c\n" +
+	"\ttimestamp\x18\b \x01(\tR\ttimestampB;Z9github.com/beavernsticks/go-stuff/events;bsgostuff_eventsb\x06proto3"
+
+var (
+	file_events_publication_proto_rawDescOnce sync.Once
+	file_events_publication_proto_rawDescData []byte
+)
+
+func file_events_publication_proto_rawDescGZIP() []byte {
+	file_events_publication_proto_rawDescOnce.Do(func() {
+		file_events_publication_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_publication_proto_rawDesc), len(file_events_publication_proto_rawDesc)))
+	})
+	return file_events_publication_proto_rawDescData
+}
+
+var file_events_publication_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_events_publication_proto_goTypes = []any{
+	(*PublicationChanged)(nil), // 0: bsgostuff_events.PublicationChanged
+}
+var file_events_publication_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_events_publication_proto_init() }
+func file_events_publication_proto_init() {
+	if File_events_publication_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_publication_proto_rawDesc), len(file_events_publication_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_publication_proto_goTypes,
+		DependencyIndexes: file_events_publication_proto_depIdxs,
+		MessageInfos:      file_events_publication_proto_msgTypes,
+	}.Build()
+	File_events_publication_proto = out.File
+	file_events_publication_proto_goTypes = nil
+	file_events_publication_proto_depIdxs = nil
+}
@@ -0,0 +1,118 @@
+package bsgostuff_infrastructure
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+
+	bsgostuff_domain "github.com/beavernsticks/go-stuff/domain"
+	"google.golang.org/grpc/codes"
+)
+
+// ErrorMapping описывает, как ошибка-сентинел передается клиентам gRPC и GraphQL
+type ErrorMapping struct {
+	// Err - ошибка-сентинел, сравнивается через errors.Is
+	Err error
+	// Code - код статуса gRPC
+	Code codes.Code
+	// Reason - причина для google.rpc.ErrorInfo; позволяет клиенту восстановить
+	// собственную ошибку сервиса, если код статуса совпадает с другими
+	Reason string
+	// Message - сообщение для клиента, если у ошибки нет собственного
+	Message string
+	// GraphQLCode и HTTPStatus попадают в extensions ошибки GraphQL
+	GraphQLCode string
+	HTTPStatus  int
+}
+
+// ErrorRegistry сопоставляет ошибки с кодами gRPC/GraphQL и обратно.
+// При поиске по ошибке приоритет у зарегистрированных позже (более конкретных) соответствий,
+// при поиске по коду статуса - у зарегистрированных раньше (базовых).
+type ErrorRegistry struct {
+	mu       sync.RWMutex
+	mappings []ErrorMapping
+	fallback ErrorMapping
+}
+
+// DefaultErrorMappings - соответствия для стандартных доменных ошибок
+var DefaultErrorMappings = []ErrorMapping{
+	{Err: context.DeadlineExceeded, Code: codes.DeadlineExceeded, Message: "request timed out", GraphQLCode: "TIMEOUT", HTTPStatus: http.StatusGatewayTimeout},
+	{Err: context.Canceled, Code: codes.Canceled, Message: "request canceled", GraphQLCode: "CANCELED", HTTPStatus: 499},
+	{Err: bsgostuff_domain.ErrForbidden, Code: codes.PermissionDenied, Message: "forbidden", GraphQLCode: "FORBIDDEN", HTTPStatus: http.StatusForbidden},
+	{Err: bsgostuff_domain.ErrInvalidArgument, Code: codes.InvalidArgument, Message: "invalid argument", GraphQLCode: "BAD_REQUEST", HTTPStatus: http.StatusBadRequest},
+	{Err: bsgostuff_domain.ErrNotFound, Code: codes.NotFound, Message: "not found", GraphQLCode: "NOT_FOUND", HTTPStatus: http.StatusNotFound},
+	{Err: bsgostuff_domain.ErrDuplicate, Code: codes.AlreadyExists, Message: "already exists", GraphQLCode: "CONFLICT", HTTPStatus: http.StatusConflict},
+	{Err: bsgostuff_domain.ErrInternal, Code: codes.Internal, Message: "internal server error", GraphQLCode: "INTERNAL_ERROR", HTTPStatus: http.StatusInternalServerError},
+}
+
+// DefaultErrorRegistry используется функциями-перехватчиками и GraphQLErrorHandler.
+// Сервисы могут регистрировать в нем собственные ошибки при старте.
+var DefaultErrorRegistry = NewErrorRegistry(DefaultErrorMappings...)
+
+// NewErrorRegistry создает реестр; неизвестные ошибки сопоставляются с ErrInternal
+func NewErrorRegistry(mappings ...ErrorMapping) *ErrorRegistry {
+	return &ErrorRegistry{
+		mappings: slices.Clone(mappings),
+		fallback: ErrorMapping{
+			Err:         bsgostuff_domain.ErrInternal,
+			Code:        codes.Internal,
+			Message:     "internal server error",
+			GraphQLCode: "INTERNAL_ERROR",
+			HTTPStatus:  http.StatusInternalServerError,
+		},
+	}
+}
+
+// Register добавляет соответствия
+func (r *ErrorRegistry) Register(mappings ...ErrorMapping) *ErrorRegistry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.mappings = append(r.mappings, mappings...)
+	return r
+}
+
+// Lookup находит соответствие для ошибки, разворачивая цепочку через errors.Is.
+// Для доменной ошибки (domain.Error) соответствие ищется только по ее Kind:
+// Cause не передается клиенту и не должен менять код статуса.
+func (r *ErrorRegistry) Lookup(err error) ErrorMapping {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if domainErr, ok := bsgostuff_domain.AsError(err); ok {
+		err = domainErr.Kind
+		if err == nil {
+			err = bsgostuff_domain.ErrInternal
+		}
+	}
+
+	for i := len(r.mappings) - 1; i >= 0; i-- {
+		if errors.Is(err, r.mappings[i].Err) {
+			return r.mappings[i]
+		}
+	}
+	return r.fallback
+}
+
+// LookupStatus находит соответствие по коду статуса и причине из ErrorInfo.
+// Совпадение по причине имеет приоритет над совпадением по коду.
+func (r *ErrorRegistry) LookupStatus(code codes.Code, reason string) ErrorMapping {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if reason != "" {
+		for i := len(r.mappings) - 1; i >= 0; i-- {
+			if r.mappings[i].Reason == reason && r.mappings[i].Code == code {
+				return r.mappings[i]
+			}
+		}
+	}
+	for _, mapping := range r.mappings {
+		if mapping.Code == code {
+			return mapping
+		}
+	}
+	return r.fallback
+}
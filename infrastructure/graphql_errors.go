@@ -3,45 +3,34 @@ package bsgostuff_infrastructure
 import (
 	"context"
 	"log/slog"
-	"net/http"
 
 	"github.com/99designs/gqlgen/graphql"
 	bsgostuff_domain "github.com/beavernsticks/go-stuff/domain"
 	"github.com/vektah/gqlparser/v2/gqlerror"
+	"google.golang.org/grpc/codes"
 )
 
 func GraphQLErrorHandler(ctx context.Context, err error) *gqlerror.Error {
+	return DefaultErrorRegistry.GraphQLErrorHandler(ctx, err)
+}
+
+func (r *ErrorRegistry) GraphQLErrorHandler(ctx context.Context, err error) *gqlerror.Error {
 	// Преобразуем ошибку в gqlerror
 	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
 
 	// Обрабатываем доменные ошибки
+	mapping := r.Lookup(err)
+	gqlErr.Extensions = map[string]interface{}{
+		"code":       mapping.GraphQLCode,
+		"httpStatus": mapping.HTTPStatus,
+	}
+	if mapping.Reason != "" {
+		gqlErr.Extensions["reason"] = mapping.Reason
+	}
 
-	switch {
-	case bsgostuff_domain.IsNotFoundError(err):
-		gqlErr.Extensions = map[string]interface{}{
-			"code":       "NOT_FOUND",
-			"httpStatus": http.StatusNotFound,
-		}
-	case bsgostuff_domain.IsForbiddenError(err):
-		gqlErr.Extensions = map[string]interface{}{
-			"code":       "FORBIDDEN",
-			"httpStatus": http.StatusForbidden,
-		}
-	case bsgostuff_domain.IsInvalidArgumentError(err):
-		gqlErr.Extensions = map[string]interface{}{
-			"code":       "BAD_REQUEST",
-			"httpStatus": http.StatusBadRequest,
-		}
-	case bsgostuff_domain.IsDuplicateError(err):
-		gqlErr.Extensions = map[string]interface{}{
-			"code":       "CONFLICT",
-			"httpStatus": http.StatusConflict,
-		}
-	default:
-		gqlErr.Extensions = map[string]interface{}{
-			"code":       "INTERNAL_ERROR",
-			"httpStatus": http.StatusInternalServerError,
-		}
+	// Добавляем детали доменной ошибки
+	if domainErr, ok := bsgostuff_domain.AsError(err); ok {
+		addGraphQLErrorDetails(gqlErr, domainErr, mapping)
 	}
 
 	slog.ErrorContext(ctx, "GraphQL error",
@@ -51,3 +40,36 @@ func GraphQLErrorHandler(ctx context.Context, err error) *gqlerror.Error {
 
 	return gqlErr
 }
+
+// addGraphQLErrorDetails добавляет в extensions причину, нарушения по полям,
+// информацию о ресурсе и метаданные доменной ошибки
+func addGraphQLErrorDetails(gqlErr *gqlerror.Error, err *bsgostuff_domain.Error, mapping ErrorMapping) {
+	if err.Message != "" && mapping.Code != codes.Internal {
+		gqlErr.Message = err.Message
+	}
+	if err.Reason != "" {
+		gqlErr.Extensions["reason"] = err.Reason
+	}
+	if len(err.Violations) > 0 {
+		violations := make([]map[string]interface{}, 0, len(err.Violations))
+		for _, violation := range err.Violations {
+			violations = append(violations, map[string]interface{}{
+				"field":   violation.Field,
+				"code":    violation.Code,
+				"message": violation.Message,
+			})
+		}
+		gqlErr.Extensions["violations"] = violations
+	}
+	if err.Resource != nil {
+		gqlErr.Extensions["resource"] = map[string]interface{}{
+			"type":        err.Resource.Type,
+			"name":        err.Resource.Name,
+			"owner":       err.Resource.Owner,
+			"description": err.Resource.Description,
+		}
+	}
+	if len(err.Metadata) > 0 {
+		gqlErr.Extensions["metadata"] = err.Metadata
+	}
+}
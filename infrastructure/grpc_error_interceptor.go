@@ -2,15 +2,42 @@ package bsgostuff_infrastructure
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log/slog"
 
 	bsgostuff_domain "github.com/beavernsticks/go-stuff/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 )
 
 func ErrorUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return DefaultErrorRegistry.UnaryServerInterceptor(ctx, req, info, handler)
+}
+
+func ErrorStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return DefaultErrorRegistry.StreamServerInterceptor(srv, stream, info, handler)
+}
+
+func ErrorClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return DefaultErrorRegistry.UnaryClientInterceptor(ctx, method, req, reply, cc, invoker, opts...)
+}
+
+func ErrorStreamClientInterceptor(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	return DefaultErrorRegistry.StreamClientInterceptor(ctx, desc, cc, method, streamer, opts...)
+}
+
+func (r *ErrorRegistry) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 	resp, err := handler(ctx, req)
 	if err == nil {
 		return resp, nil
@@ -18,26 +45,10 @@ func ErrorUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.Unar
 
 	slog.Error("gRPC error", slog.String("method", info.FullMethod), slog.Any("error", err))
 
-	// Обрабатываем стандартные ошибки
-	switch err {
-	case context.DeadlineExceeded:
-		return nil, status.Error(codes.DeadlineExceeded, "request timed out")
-	case context.Canceled:
-		return nil, status.Error(codes.Canceled, "request canceled")
-	case bsgostuff_domain.ErrForbidden:
-		return nil, status.Error(codes.PermissionDenied, "forbidden")
-	case bsgostuff_domain.ErrInvalidArgument:
-		return nil, status.Error(codes.InvalidArgument, "invalid argument")
-	case bsgostuff_domain.ErrNotFound:
-		return nil, status.Error(codes.NotFound, "not found")
-	case bsgostuff_domain.ErrDuplicate:
-		return nil, status.Error(codes.AlreadyExists, "already exists")
-	default:
-		return nil, status.Error(codes.Internal, "internal server error")
-	}
+	return nil, r.ToStatus(err)
 }
 
-func ErrorStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+func (r *ErrorRegistry) StreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 	err := handler(srv, stream)
 	if err == nil {
 		return nil
@@ -45,56 +56,21 @@ func ErrorStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grp
 
 	slog.Error("gRPC error", slog.String("method", info.FullMethod), slog.Any("error", err))
 
-	// Обрабатываем стандартные ошибки
-	switch err {
-	case context.DeadlineExceeded:
-		return status.Error(codes.DeadlineExceeded, "request timed out")
-	case context.Canceled:
-		return status.Error(codes.Canceled, "request canceled")
-	case bsgostuff_domain.ErrForbidden:
-		return status.Error(codes.PermissionDenied, "forbidden")
-	case bsgostuff_domain.ErrInvalidArgument:
-		return status.Error(codes.InvalidArgument, "invalid argument")
-	case bsgostuff_domain.ErrNotFound:
-		return status.Error(codes.NotFound, "not found")
-	case bsgostuff_domain.ErrDuplicate:
-		return status.Error(codes.AlreadyExists, "already exists")
-	default:
-		return status.Error(codes.Internal, "internal server error")
-	}
+	return r.ToStatus(err)
 }
 
-func ErrorClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+func (r *ErrorRegistry) UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 	err := invoker(ctx, method, req, reply, cc, opts...)
 	if err != nil {
 		slog.Error("gRPC error", slog.String("method", method), slog.Any("error", err))
 
-		if status, ok := status.FromError(err); ok {
-			switch status.Code() {
-			case codes.DeadlineExceeded:
-				return context.DeadlineExceeded
-			case codes.Canceled:
-				return context.Canceled
-			case codes.PermissionDenied:
-				return bsgostuff_domain.ErrForbidden
-			case codes.InvalidArgument:
-				return bsgostuff_domain.ErrInvalidArgument
-			case codes.NotFound:
-				return bsgostuff_domain.ErrNotFound
-			case codes.AlreadyExists:
-				return bsgostuff_domain.ErrDuplicate
-			default:
-				return bsgostuff_domain.ErrInternal
-			}
-		}
-
-		return bsgostuff_domain.ErrInternal
+		return r.FromStatus(err)
 	}
 
 	return nil
 }
 
-func ErrorStreamClientInterceptor(
+func (r *ErrorRegistry) StreamClientInterceptor(
 	ctx context.Context,
 	desc *grpc.StreamDesc,
 	cc *grpc.ClientConn,
@@ -102,32 +78,153 @@ func ErrorStreamClientInterceptor(
 	streamer grpc.Streamer,
 	opts ...grpc.CallOption,
 ) (grpc.ClientStream, error) {
-	// Создаем обертку для ClientStream
 	clientStream, err := streamer(ctx, desc, cc, method, opts...)
 	if err != nil {
 		slog.Error("gRPC error", slog.String("method", method), slog.Any("error", err))
 
-		if status, ok := status.FromError(err); ok {
-			switch status.Code() {
-			case codes.DeadlineExceeded:
-				return nil, context.DeadlineExceeded
-			case codes.Canceled:
-				return nil, context.Canceled
-			case codes.PermissionDenied:
-				return nil, bsgostuff_domain.ErrForbidden
-			case codes.InvalidArgument:
-				return nil, bsgostuff_domain.ErrInvalidArgument
-			case codes.NotFound:
-				return nil, bsgostuff_domain.ErrNotFound
-			case codes.AlreadyExists:
-				return nil, bsgostuff_domain.ErrDuplicate
-			default:
-				return nil, bsgostuff_domain.ErrInternal
+		return nil, r.FromStatus(err)
+	}
+
+	// Создаем обертку для ClientStream, чтобы преобразовывать ошибки посреди потока
+	return &errorClientStream{ClientStream: clientStream, registry: r, method: method}, nil
+}
+
+// errorClientStream преобразует ошибки RecvMsg/SendMsg в доменные
+type errorClientStream struct {
+	grpc.ClientStream
+	registry *ErrorRegistry
+	method   string
+}
+
+func (s *errorClientStream) RecvMsg(m interface{}) error {
+	return s.translate(s.ClientStream.RecvMsg(m))
+}
+
+func (s *errorClientStream) SendMsg(m interface{}) error {
+	return s.translate(s.ClientStream.SendMsg(m))
+}
+
+func (s *errorClientStream) translate(err error) error {
+	// io.EOF сигнализирует о штатном завершении потока
+	if err == nil || errors.Is(err, io.EOF) {
+		return err
+	}
+
+	slog.Error("gRPC error", slog.String("method", s.method), slog.Any("error", err))
+
+	return s.registry.FromStatus(err)
+}
+
+// ToStatus преобразует ошибку в статус gRPC.
+// Детали доменной ошибки передаются как google.rpc BadRequest, ResourceInfo и ErrorInfo.
+func (r *ErrorRegistry) ToStatus(err error) error {
+	mapping := r.Lookup(err)
+
+	domainErr, ok := bsgostuff_domain.AsError(err)
+	if !ok {
+		domainErr = &bsgostuff_domain.Error{Kind: mapping.Err}
+	}
+
+	message := mapping.Message
+	// Сообщения внутренних ошибок клиенту не раскрываем
+	if domainErr.Message != "" && mapping.Code != codes.Internal {
+		message = domainErr.Message
+	}
+
+	reason := domainErr.Reason
+	if reason == "" {
+		reason = mapping.Reason
+	}
+
+	details := make([]protoadapt.MessageV1, 0, 3)
+	if len(domainErr.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range domainErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Message,
+				Reason:      violation.Code,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if domainErr.Resource != nil {
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: domainErr.Resource.Type,
+			ResourceName: domainErr.Resource.Name,
+			Owner:        domainErr.Resource.Owner,
+			Description:  domainErr.Resource.Description,
+		})
+	}
+	if reason != "" || len(domainErr.Metadata) > 0 {
+		details = append(details, &errdetails.ErrorInfo{
+			Reason:   reason,
+			Metadata: domainErr.Metadata,
+		})
+	}
+
+	st := status.New(mapping.Code, message)
+	if len(details) == 0 {
+		return st.Err()
+	}
+	withDetails, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		slog.Error("gRPC error details", slog.Any("error", detailsErr))
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// FromStatus восстанавливает доменную ошибку из статуса gRPC.
+// Без деталей и собственного сообщения возвращается сама ошибка-сентинел,
+// чтобы сравнение err == ErrNotFound у вызывающей стороны продолжало работать.
+func (r *ErrorRegistry) FromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return r.fallback.Err
+	}
+
+	domainErr := &bsgostuff_domain.Error{Message: st.Message()}
+	hasDetails := false
+
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, violation := range detail.GetFieldViolations() {
+				domainErr.Violations = append(domainErr.Violations, bsgostuff_domain.FieldViolation{
+					Field:   violation.GetField(),
+					Code:    violation.GetReason(),
+					Message: violation.GetDescription(),
+				})
 			}
+			hasDetails = true
+		case *errdetails.ResourceInfo:
+			domainErr.Resource = &bsgostuff_domain.ResourceInfo{
+				Type:        detail.GetResourceType(),
+				Name:        detail.GetResourceName(),
+				Owner:       detail.GetOwner(),
+				Description: detail.GetDescription(),
+			}
+			hasDetails = true
+		case *errdetails.ErrorInfo:
+			domainErr.Reason = detail.GetReason()
+			domainErr.Metadata = detail.GetMetadata()
+			hasDetails = hasDetails || len(domainErr.Metadata) > 0
 		}
+	}
 
-		return nil, bsgostuff_domain.ErrInternal
+	mapping := r.LookupStatus(st.Code(), domainErr.Reason)
+	domainErr.Kind = mapping.Err
+	if domainErr.Reason != mapping.Reason {
+		hasDetails = true
 	}
 
-	return clientStream, nil
+	switch {
+	case mapping.Code == codes.Internal, mapping.Code == codes.DeadlineExceeded, mapping.Code == codes.Canceled:
+		return mapping.Err
+	case !hasDetails && (st.Message() == mapping.Message || st.Message() == mapping.Err.Error()):
+		return mapping.Err
+	default:
+		return domainErr
+	}
 }
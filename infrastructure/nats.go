@@ -10,7 +10,6 @@ import (
 	"time"
 
 	bsgostuff_config "github.com/beavernsticks/go-stuff/config"
-	bsgostuff_events "github.com/beavernsticks/go-stuff/events"
 	"github.com/nats-io/nats.go"
 	"google.golang.org/protobuf/proto"
 )
@@ -19,6 +18,7 @@ type NATSBroker struct {
 	conn      *nats.Conn
 	js        nats.JetStreamContext
 	envPrefix string
+	dlq       DLQRetention
 	mu        sync.Mutex
 	subs      map[string]*nats.Subscription
 }
@@ -49,6 +49,7 @@ func NewNATSBroker(cfg bsgostuff_config.NATS) (*NATSBroker, error) {
 		conn:      conn,
 		js:        js,
 		envPrefix: prefix,
+		dlq:       DLQRetention{MaxAge: cfg.DLQMaxAge, MaxBytes: cfg.DLQMaxBytes},
 		subs:      make(map[string]*nats.Subscription),
 	}, nil
 }
@@ -63,14 +64,28 @@ func MustNewNATSBroker(cfg bsgostuff_config.NATS) *NATSBroker {
 }
 
 func (b *NATSBroker) Publish(ctx context.Context, topic string, msg proto.Message, opts ...nats.PubOpt) error {
-	fullTopic := b.fullTopic(topic)
 	payload, err := proto.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("proto marshal failed: %w", err)
 	}
 
+	return b.PublishRaw(ctx, topic, payload, opts...)
+}
+
+// PublishRaw публикует уже сериализованное сообщение (например, из outbox)
+func (b *NATSBroker) PublishRaw(ctx context.Context, topic string, payload []byte, opts ...nats.PubOpt) error {
+	return b.publish(ctx, b.fullTopic(topic), payload, opts...)
+}
+
+// publish публикует сообщение в тему с уже добавленным префиксом окружения
+func (b *NATSBroker) publish(ctx context.Context, subject string, payload []byte, opts ...nats.PubOpt) error {
+	return b.publishMsg(ctx, &nats.Msg{Subject: subject, Data: payload}, opts...)
+}
+
+// publishMsg публикует сообщение с заголовками (тема - с префиксом окружения)
+func (b *NATSBroker) publishMsg(ctx context.Context, msg *nats.Msg, opts ...nats.PubOpt) error {
 	return b.retry(ctx, 3, 100*time.Millisecond, func() error {
-		_, err := b.js.Publish(fullTopic, payload, opts...)
+		_, err := b.js.PublishMsg(msg, opts...)
 		if errors.Is(err, nats.ErrNoResponders) {
 			return fmt.Errorf("publish failed (no responders): %w", err)
 		}
@@ -78,6 +93,7 @@ func (b *NATSBroker) Publish(ctx context.Context, topic string, msg proto.Messag
 	})
 }
 
+// Subscribe подписывает группу на топик с параметрами по умолчанию (см. SubscribeWithOptions)
 func (b *NATSBroker) Subscribe(
 	parentCtx context.Context,
 	topic string,
@@ -85,67 +101,24 @@ func (b *NATSBroker) Subscribe(
 	handler func(context.Context, proto.Message) error,
 	protoTemplate proto.Message,
 ) error {
-	fullTopic := b.fullTopic(topic)
-	durableName := fmt.Sprintf("%s-%s", b.envPrefix, queueGroup)
-	dlqTopic := b.fullTopic(fmt.Sprintf("dlq.%s.%s", queueGroup, topic))
-
-	_, err := b.js.AddStream(&nats.StreamConfig{
-		Name:      fmt.Sprintf("DLQ_%s", durableName),
-		Subjects:  []string{dlqTopic},
-		Retention: nats.LimitsPolicy,
-	})
-	if err != nil && !errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
-		return fmt.Errorf("create DLQ stream failed: %w", err)
-	}
-
-	handlerCtx, cancel := context.WithCancel(parentCtx)
-	defer cancel()
-
-	sub, err := b.js.QueueSubscribe(
-		fullTopic,
-		queueGroup,
-		func(msg *nats.Msg) {
-			event := proto.Clone(protoTemplate)
-			if err := proto.Unmarshal(msg.Data, event); err != nil {
-				log.Printf("[ERROR] unmarshal failed: %v", err)
-				_ = msg.Term()
-				return
-			}
+	return b.SubscribeWithOptions(parentCtx, topic, queueGroup, handler, protoTemplate, SubscribeOptions{})
+}
 
-			msgCtx, cancel := context.WithTimeout(handlerCtx, 10*time.Second)
-			defer cancel()
-
-			processErr := b.retry(msgCtx, 3, 1*time.Second, func() error {
-				return handler(msgCtx, event)
-			})
-
-			if processErr != nil {
-				log.Printf("[WARN] sending to DLQ after retries: %v", processErr)
-				_ = b.Publish(context.Background(), dlqTopic, &bsgostuff_events.DeadLetter{
-					OriginalTopic: fullTopic,
-					Payload:       msg.Data,
-					Error:         processErr.Error(),
-					Timestamp:     time.Now().Format(time.RFC3339),
-				})
-				_ = msg.Term()
-			} else {
-				_ = msg.Ack()
-			}
-		},
-		nats.Durable(durableName),
-		nats.ManualAck(),
-		nats.AckWait(30*time.Second),
-		nats.Context(parentCtx),
-	)
-	if err != nil {
-		return err
+// SubscribeWithOptions подписывает группу на топик; сообщения декодируются в копию protoTemplate
+// кодеком из заголовка Content-Type (по умолчанию ProtoCodec). Типизированный вариант - SubscribeWithOptions[T].
+func (b *NATSBroker) SubscribeWithOptions(
+	parentCtx context.Context,
+	topic string,
+	queueGroup string,
+	handler func(context.Context, proto.Message) error,
+	protoTemplate proto.Message,
+	opts SubscribeOptions,
+) error {
+	decode := func(msg *nats.Msg) (proto.Message, error) {
+		event := proto.Clone(protoTemplate)
+		return event, decodeMsg(msg, ProtoCodec, event)
 	}
-
-	b.mu.Lock()
-	b.subs[fmt.Sprintf("%s|%s", fullTopic, queueGroup)] = sub
-	b.mu.Unlock()
-
-	return nil
+	return b.subscribe(parentCtx, topic, queueGroup, decode, handler, opts)
 }
 
 func (b *NATSBroker) retry(ctx context.Context, maxAttempts int, initialDelay time.Duration, fn func() error) error {
@@ -186,8 +159,9 @@ func isRetriable(err error) bool {
 		errors.Is(err, context.DeadlineExceeded)
 }
 
+// fullTopic добавляет к топику префикс окружения (envPrefix уже оканчивается точкой)
 func (b *NATSBroker) fullTopic(topic string) string {
-	return fmt.Sprintf("%s.%s", b.envPrefix, topic)
+	return b.envPrefix + topic
 }
 
 func (b *NATSBroker) Close() {
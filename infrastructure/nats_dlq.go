@@ -0,0 +1,349 @@
+package bsgostuff_infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	bsgostuff_events "github.com/beavernsticks/go-stuff/events"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// DLQRetention - ограничения хранения DLQ-потока; нулевые значения - без ограничений
+type DLQRetention struct {
+	MaxAge   time.Duration
+	MaxBytes int64
+}
+
+// DLQStream - сведения о DLQ-потоке группы подписчиков
+type DLQStream struct {
+	QueueGroup string
+	Stream     string
+	Messages   uint64
+	Bytes      uint64
+	FirstSeq   uint64
+	LastSeq    uint64
+	Retention  DLQRetention
+}
+
+// DeadLetter - сообщение DLQ-потока
+type DeadLetter struct {
+	Sequence uint64
+	Subject  string
+	// Received - время попадания в DLQ
+	Received time.Time
+	Letter   *bsgostuff_events.DeadLetter
+}
+
+// DLQStreams возвращает DLQ-потоки всех групп подписчиков текущего окружения.
+// Потоки отбираются по теме (<prefix>dlq.<group>.>), а не по имени: имена потоков
+// окружений с общим началом (prod и prod-eu) пересекаются.
+func (b *NATSBroker) DLQStreams(ctx context.Context) ([]DLQStream, error) {
+	subjectPrefix := b.fullTopic("dlq.")
+
+	streams := make([]DLQStream, 0)
+	for info := range b.js.StreamsInfo(nats.StreamListFilter(subjectPrefix+">"), nats.Context(ctx)) {
+		for _, subject := range info.Config.Subjects {
+			queueGroup, ok := strings.CutPrefix(subject, subjectPrefix)
+			queueGroup, suffixed := strings.CutSuffix(queueGroup, ".>")
+			if !ok || !suffixed || queueGroup == "" || info.Config.Name != b.dlqStreamName(queueGroup) {
+				continue
+			}
+			streams = append(streams, dlqStreamFromInfo(queueGroup, info))
+			break
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(streams, func(a, b DLQStream) int { return strings.Compare(a.QueueGroup, b.QueueGroup) })
+	return streams, nil
+}
+
+// DLQStream возвращает сведения о DLQ-потоке группы подписчиков
+func (b *NATSBroker) DLQStream(ctx context.Context, queueGroup string) (DLQStream, error) {
+	info, err := b.js.StreamInfo(b.dlqStreamName(queueGroup), nats.Context(ctx))
+	if err != nil {
+		return DLQStream{}, fmt.Errorf("DLQ stream info failed: %w", err)
+	}
+	return dlqStreamFromInfo(queueGroup, info), nil
+}
+
+func dlqStreamFromInfo(queueGroup string, info *nats.StreamInfo) DLQStream {
+	stream := DLQStream{
+		QueueGroup: queueGroup,
+		Stream:     info.Config.Name,
+		Messages:   info.State.Msgs,
+		Bytes:      info.State.Bytes,
+		FirstSeq:   info.State.FirstSeq,
+		LastSeq:    info.State.LastSeq,
+		Retention:  DLQRetention{MaxAge: info.Config.MaxAge},
+	}
+	if info.Config.MaxBytes > 0 {
+		stream.Retention.MaxBytes = info.Config.MaxBytes
+	}
+	return stream
+}
+
+// DeadLetters возвращает до limit сообщений DLQ группы, начиная с последовательности fromSeq
+// (0 - с первого сообщения); limit <= 0 - без ограничения.
+// Сообщения читаются прямым запросом следующего сообщения (DirectGetNext), поэтому
+// пропуски после удаления не стоят лишних запросов.
+func (b *NATSBroker) DeadLetters(ctx context.Context, queueGroup string, fromSeq uint64, limit int) ([]DeadLetter, error) {
+	name := b.dlqStreamName(queueGroup)
+	if err := b.allowDirectGet(ctx, name); err != nil {
+		return nil, err
+	}
+
+	letters := make([]DeadLetter, 0)
+	for seq := max(fromSeq, 1); limit <= 0 || len(letters) < limit; {
+		msg, err := b.js.GetMsg(name, seq, nats.DirectGetNext(b.dlqTopic(queueGroup, ">")), nats.Context(ctx))
+		if errors.Is(err, nats.ErrMsgNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("DLQ message after %d: %w", seq, err)
+		}
+
+		letter, err := deadLetterFromMsg(msg)
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, letter)
+		seq = msg.Sequence + 1
+	}
+
+	return letters, nil
+}
+
+// DeadLetter возвращает сообщение DLQ группы по последовательности
+func (b *NATSBroker) DeadLetter(ctx context.Context, queueGroup string, seq uint64) (DeadLetter, error) {
+	msg, err := b.js.GetMsg(b.dlqStreamName(queueGroup), seq, nats.Context(ctx))
+	if err != nil {
+		return DeadLetter{}, fmt.Errorf("DLQ message %d: %w", seq, err)
+	}
+	return deadLetterFromMsg(msg)
+}
+
+func deadLetterFromMsg(msg *nats.RawStreamMsg) (DeadLetter, error) {
+	letter := &bsgostuff_events.DeadLetter{}
+	if err := proto.Unmarshal(msg.Data, letter); err != nil {
+		return DeadLetter{}, fmt.Errorf("DLQ message %d unmarshal failed: %w", msg.Sequence, err)
+	}
+
+	return DeadLetter{Sequence: msg.Sequence, Subject: msg.Subject, Received: msg.Time, Letter: letter}, nil
+}
+
+// ReplayDeadLetters публикует сообщения DLQ группы обратно в OriginalTopic и удаляет их из DLQ.
+// Без seqs переигрываются все сообщения, находящиеся в DLQ на момент вызова.
+// Возвращает число переигранных сообщений.
+func (b *NATSBroker) ReplayDeadLetters(ctx context.Context, queueGroup string, seqs ...uint64) (int, error) {
+	letters, err := b.selectDeadLetters(ctx, queueGroup, seqs)
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	for _, letter := range letters {
+		// OriginalTopic уже содержит префикс окружения; кодек сохраняется в заголовке
+		msg := &nats.Msg{Subject: letter.Letter.GetOriginalTopic(), Data: letter.Letter.GetPayload()}
+		if contentType := letter.Letter.GetContentType(); contentType != "" {
+			msg.Header = nats.Header{ContentTypeHeader: []string{contentType}}
+		}
+		if _, err := b.js.PublishMsg(msg, nats.Context(ctx)); err != nil {
+			return replayed, fmt.Errorf("DLQ message %d replay failed: %w", letter.Sequence, err)
+		}
+		if err := b.js.DeleteMsg(b.dlqStreamName(queueGroup), letter.Sequence, nats.Context(ctx)); err != nil {
+			return replayed, fmt.Errorf("DLQ message %d delete failed: %w", letter.Sequence, err)
+		}
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+// PurgeDeadLetters удаляет сообщения DLQ группы; без seqs - все сообщения.
+// Возвращает число удаленных сообщений.
+func (b *NATSBroker) PurgeDeadLetters(ctx context.Context, queueGroup string, seqs ...uint64) (int, error) {
+	name := b.dlqStreamName(queueGroup)
+
+	if len(seqs) == 0 {
+		stream, err := b.DLQStream(ctx, queueGroup)
+		if err != nil {
+			return 0, err
+		}
+		if err := b.js.PurgeStream(name, nats.Context(ctx)); err != nil {
+			return 0, fmt.Errorf("DLQ purge failed: %w", err)
+		}
+		return int(stream.Messages), nil
+	}
+
+	purged := 0
+	for _, seq := range seqs {
+		err := b.js.DeleteMsg(name, seq, nats.Context(ctx))
+		if errors.Is(err, nats.ErrMsgNotFound) {
+			continue
+		}
+		if err != nil {
+			return purged, fmt.Errorf("DLQ message %d delete failed: %w", seq, err)
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// SetDLQRetention задает ограничения хранения DLQ-потока группы
+func (b *NATSBroker) SetDLQRetention(ctx context.Context, queueGroup string, retention DLQRetention) error {
+	info, err := b.js.StreamInfo(b.dlqStreamName(queueGroup), nats.Context(ctx))
+	if err != nil {
+		return fmt.Errorf("DLQ stream info failed: %w", err)
+	}
+
+	cfg := info.Config
+	cfg.MaxAge = retention.MaxAge
+	cfg.MaxBytes = dlqMaxBytes(retention.MaxBytes)
+	if _, err := b.js.UpdateStream(&cfg, nats.Context(ctx)); err != nil {
+		return fmt.Errorf("DLQ stream update failed: %w", err)
+	}
+
+	return nil
+}
+
+// DecodeDeadLetterPayload декодирует Payload в сообщение типа typeName (полное имя proto-сообщения)
+// кодеком из ContentType письма.
+// resolver == nil - типы, зарегистрированные в программе (protoregistry.GlobalTypes).
+func DecodeDeadLetterPayload(letter *bsgostuff_events.DeadLetter, typeName string, resolver protoregistry.MessageTypeResolver) (proto.Message, error) {
+	if resolver == nil {
+		resolver = protoregistry.GlobalTypes
+	}
+
+	messageType, err := resolver.FindMessageByName(protoreflect.FullName(typeName))
+	if err != nil {
+		return nil, fmt.Errorf("message type %q: %w", typeName, err)
+	}
+
+	codec, err := codecFor(letter.GetContentType(), ProtoCodec)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := messageType.New().Interface()
+	if err := codec.Unmarshal(letter.GetPayload(), msg); err != nil {
+		return nil, fmt.Errorf("payload unmarshal as %q failed: %w", typeName, err)
+	}
+	return msg, nil
+}
+
+// selectDeadLetters возвращает сообщения по seqs или все сообщения DLQ группы
+func (b *NATSBroker) selectDeadLetters(ctx context.Context, queueGroup string, seqs []uint64) ([]DeadLetter, error) {
+	if len(seqs) == 0 {
+		return b.DeadLetters(ctx, queueGroup, 0, 0)
+	}
+
+	letters := make([]DeadLetter, 0, len(seqs))
+	for _, seq := range seqs {
+		letter, err := b.DeadLetter(ctx, queueGroup, seq)
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, letter)
+	}
+	return letters, nil
+}
+
+// ensureDLQStream создает DLQ-поток группы для всех ее топиков.
+// Существующий поток дополняется темой группы и ограничениями хранения из конфигурации.
+func (b *NATSBroker) ensureDLQStream(queueGroup string) error {
+	cfg := nats.StreamConfig{
+		Name:      b.dlqStreamName(queueGroup),
+		Subjects:  []string{b.dlqTopic(queueGroup, ">")},
+		Retention: nats.LimitsPolicy,
+		MaxAge:    b.dlq.MaxAge,
+		MaxBytes:  dlqMaxBytes(b.dlq.MaxBytes),
+		// Прямое чтение нужно DeadLetters
+		AllowDirect: true,
+	}
+
+	_, err := b.js.AddStream(&cfg)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
+		return fmt.Errorf("create DLQ stream failed: %w", err)
+	}
+
+	info, err := b.js.StreamInfo(cfg.Name)
+	if err != nil {
+		return fmt.Errorf("DLQ stream info failed: %w", err)
+	}
+
+	existing, changed := info.Config, false
+	if !slices.Contains(existing.Subjects, cfg.Subjects[0]) {
+		existing.Subjects, changed = append(existing.Subjects, cfg.Subjects[0]), true
+	}
+	if !existing.AllowDirect {
+		existing.AllowDirect, changed = true, true
+	}
+	if b.dlq.MaxAge > 0 && existing.MaxAge != cfg.MaxAge {
+		existing.MaxAge, changed = cfg.MaxAge, true
+	}
+	if b.dlq.MaxBytes > 0 && existing.MaxBytes != cfg.MaxBytes {
+		existing.MaxBytes, changed = cfg.MaxBytes, true
+	}
+	if !changed {
+		return nil
+	}
+
+	if _, err := b.js.UpdateStream(&existing); err != nil {
+		return fmt.Errorf("update DLQ stream failed: %w", err)
+	}
+	return nil
+}
+
+// allowDirectGet включает прямое чтение у DLQ-потока, созданного до его появления
+func (b *NATSBroker) allowDirectGet(ctx context.Context, name string) error {
+	info, err := b.js.StreamInfo(name, nats.Context(ctx))
+	if err != nil {
+		return fmt.Errorf("DLQ stream info failed: %w", err)
+	}
+	if info.Config.AllowDirect {
+		return nil
+	}
+
+	cfg := info.Config
+	cfg.AllowDirect = true
+	if _, err := b.js.UpdateStream(&cfg, nats.Context(ctx)); err != nil {
+		return fmt.Errorf("DLQ stream update failed: %w", err)
+	}
+	return nil
+}
+
+// durableName - имя consumer-а группы; точки префикса недопустимы в именах JetStream
+func (b *NATSBroker) durableName(queueGroup string) string {
+	prefix := strings.ReplaceAll(strings.TrimSuffix(b.envPrefix, "."), ".", "_")
+	return fmt.Sprintf("%s-%s", prefix, queueGroup)
+}
+
+func (b *NATSBroker) dlqStreamName(queueGroup string) string {
+	return fmt.Sprintf("DLQ_%s", b.durableName(queueGroup))
+}
+
+func (b *NATSBroker) dlqTopic(queueGroup, topic string) string {
+	return b.fullTopic(fmt.Sprintf("dlq.%s.%s", queueGroup, topic))
+}
+
+// dlqMaxBytes переводит ограничение в формат JetStream (-1 - без ограничения)
+func dlqMaxBytes(maxBytes int64) int64 {
+	if maxBytes <= 0 {
+		return -1
+	}
+	return maxBytes
+}
@@ -0,0 +1,281 @@
+package bsgostuff_infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+// PullOptions - параметры pull-подписки. SubscribeOptions задают обработку сообщений,
+// MaxInFlight - размер пула обработчиков (для PullSubscribeBatch - число одновременно обрабатываемых пачек),
+// MaxAckPending для PullSubscribeBatch по умолчанию - MaxInFlight * BatchSize.
+type PullOptions struct {
+	SubscribeOptions
+	// BatchSize - максимум сообщений за один запрос (по умолчанию 100)
+	BatchSize int
+	// MaxWait - сколько ждать накопления пачки (по умолчанию 5s)
+	MaxWait time.Duration
+}
+
+func (o PullOptions) withDefaults(batch bool) PullOptions {
+	if o.BatchSize <= 0 {
+		o.BatchSize = 100
+	}
+	if o.MaxWait <= 0 {
+		o.MaxWait = 5 * time.Second
+	}
+	if batch && o.MaxAckPending <= 0 {
+		o.MaxAckPending = max(o.MaxInFlight, 1) * o.BatchSize
+	}
+	o.SubscribeOptions = o.SubscribeOptions.withDefaults()
+	return o
+}
+
+// BatchError - ошибки обработчика пачки по индексам сообщений: остальные сообщения пачки подтверждаются.
+// Любая другая ошибка обработчика относится ко всем сообщениям пачки.
+type BatchError map[int]error
+
+func (e BatchError) Error() string {
+	indexes := make([]int, 0, len(e))
+	for i := range e {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	parts := make([]string, len(indexes))
+	for i, index := range indexes {
+		parts[i] = fmt.Sprintf("[%d] %v", index, e[index])
+	}
+	return fmt.Sprintf("batch failed for %d message(s): %s", len(e), strings.Join(parts, "; "))
+}
+
+// PullSubscribe подписывает группу на топик через pull-consumer: сообщения запрашиваются пачками
+// до BatchSize по числу свободных обработчиков пула из MaxInFlight.
+// Подтверждение, повторы и DLQ - как в SubscribeWithOptions.
+// Подписка работает, пока не отменен ctx или не закрыт брокер.
+func (b *NATSBroker) PullSubscribe(
+	ctx context.Context,
+	topic string,
+	queueGroup string,
+	handler func(context.Context, proto.Message) error,
+	protoTemplate proto.Message,
+	opts PullOptions,
+) error {
+	decode := func(msg *nats.Msg) (proto.Message, error) {
+		event := proto.Clone(protoTemplate)
+		return event, decodeMsg(msg, ProtoCodec, event)
+	}
+	return b.pullSubscribe(ctx, topic, queueGroup, &subscription{decode: decode, handler: handler}, opts)
+}
+
+// PullSubscribeBatch подписывает группу на топик через pull-consumer с обработчиком пачки
+// (например, для массовой записи в БД). Сообщения подтверждаются или откладываются по отдельности:
+// BatchError указывает неудачные сообщения, любая другая ошибка - всю пачку.
+func (b *NATSBroker) PullSubscribeBatch(
+	ctx context.Context,
+	topic string,
+	queueGroup string,
+	handler func(context.Context, []proto.Message) error,
+	protoTemplate proto.Message,
+	opts PullOptions,
+) error {
+	decode := func(msg *nats.Msg) (proto.Message, error) {
+		event := proto.Clone(protoTemplate)
+		return event, decodeMsg(msg, ProtoCodec, event)
+	}
+	return b.pullSubscribe(ctx, topic, queueGroup, &subscription{decode: decode, batchHandler: handler}, opts)
+}
+
+// PullSubscribe - типизированный вариант NATSBroker.PullSubscribe
+func PullSubscribe[T proto.Message](ctx context.Context, b *NATSBroker, topic Topic[T], queueGroup string, handler func(context.Context, T) error, opts PullOptions) error {
+	s := &subscription{
+		decode: topic.decode,
+		handler: func(ctx context.Context, event proto.Message) error {
+			return handler(ctx, event.(T))
+		},
+	}
+	return b.pullSubscribe(ctx, topic.subject, queueGroup, s, opts)
+}
+
+// PullSubscribeBatch - типизированный вариант NATSBroker.PullSubscribeBatch
+func PullSubscribeBatch[T proto.Message](ctx context.Context, b *NATSBroker, topic Topic[T], queueGroup string, handler func(context.Context, []T) error, opts PullOptions) error {
+	s := &subscription{
+		decode: topic.decode,
+		batchHandler: func(ctx context.Context, events []proto.Message) error {
+			typed := make([]T, len(events))
+			for i, event := range events {
+				typed[i] = event.(T)
+			}
+			return handler(ctx, typed)
+		},
+	}
+	return b.pullSubscribe(ctx, topic.subject, queueGroup, s, opts)
+}
+
+// pullSubscribe создает pull-consumer и запускает цикл запроса пачек.
+// Durable-consumer группы - pull; группа не может одновременно использовать push-подписку (Subscribe).
+func (b *NATSBroker) pullSubscribe(ctx context.Context, topic, queueGroup string, s *subscription, opts PullOptions) error {
+	opts = opts.withDefaults(s.batchHandler != nil)
+	s.fullTopic = b.fullTopic(topic)
+	s.dlqTopic = b.dlqTopic(queueGroup, topic)
+	s.opts = opts.SubscribeOptions
+
+	if !s.opts.DisableDLQ {
+		if err := b.ensureDLQStream(queueGroup); err != nil {
+			return err
+		}
+	}
+
+	durable := b.durableName(queueGroup)
+	if err := b.reconcileConsumer(ctx, s.fullTopic, durable, s.opts); err != nil {
+		return err
+	}
+
+	sub, err := b.js.PullSubscribe(
+		s.fullTopic,
+		durable,
+		nats.AckWait(s.opts.AckWait),
+		nats.MaxDeliver(s.opts.MaxDeliver),
+		nats.MaxAckPending(s.opts.MaxAckPending),
+		nats.Context(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("pull subscribe failed: %w", err)
+	}
+
+	b.mu.Lock()
+	b.subs[fmt.Sprintf("%s|%s", s.fullTopic, queueGroup)] = sub
+	b.mu.Unlock()
+
+	go b.pull(ctx, sub, s, opts)
+
+	return nil
+}
+
+// pull запрашивает пачки и раздает их пулу обработчиков. Сообщения запрашиваются только для свободных
+// обработчиков (не больше BatchSize): полученное сообщение сразу обрабатывается, а не ждет в очереди,
+// пока истекает его AckWait. Обработчик пачки занимает один слот пула.
+func (b *NATSBroker) pull(ctx context.Context, sub *nats.Subscription, s *subscription, opts PullOptions) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	workers := make(chan struct{}, s.opts.MaxInFlight)
+	release := func(n int) {
+		for range n {
+			<-workers
+		}
+	}
+	run := func(fn func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer release(1)
+			fn()
+		}()
+	}
+
+	for ctx.Err() == nil && sub.IsValid() {
+		// Ждем хотя бы один свободный обработчик и занимаем остальные свободные
+		select {
+		case workers <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		slots, size := 1, opts.BatchSize
+		if s.batchHandler == nil {
+			slots += acquireFree(workers, opts.BatchSize-1)
+			size = slots
+		}
+
+		// Fetch не принимает одновременно MaxWait и контекст, поэтому ожидание задается дедлайном
+		fetchCtx, cancel := context.WithTimeout(ctx, opts.MaxWait)
+		msgs, err := sub.Fetch(size, nats.Context(fetchCtx))
+		cancel()
+
+		switch {
+		case errors.Is(err, nats.ErrTimeout), errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+			// Пустая пачка за MaxWait или остановка
+		case errors.Is(err, nats.ErrBadSubscription), errors.Is(err, nats.ErrConnectionClosed):
+			release(slots)
+			return
+		case err != nil:
+			release(slots)
+			log.Printf("[WARN] pull fetch failed: %v", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+
+		if s.batchHandler != nil {
+			if len(msgs) == 0 {
+				release(slots)
+				continue
+			}
+			run(func() { b.handleBatch(ctx, s, msgs) })
+			continue
+		}
+		for _, msg := range msgs {
+			run(func() { b.handle(ctx, s, msg) })
+		}
+		release(slots - len(msgs))
+	}
+}
+
+// acquireFree занимает до n свободных слотов пула без ожидания и возвращает их число
+func acquireFree(workers chan struct{}, n int) int {
+	for acquired := 0; acquired < n; acquired++ {
+		select {
+		case workers <- struct{}{}:
+		default:
+			return acquired
+		}
+	}
+	return n
+}
+
+// handleBatch обрабатывает пачку: нераспознанные сообщения уходят в DLQ, остальные - в обработчик пачки
+func (b *NATSBroker) handleBatch(ctx context.Context, s *subscription, msgs []*nats.Msg) {
+	events := make([]proto.Message, 0, len(msgs))
+	decoded := make([]*nats.Msg, 0, len(msgs))
+	for _, msg := range msgs {
+		event, err := s.decode(msg)
+		if err != nil {
+			log.Printf("[ERROR] unmarshal failed: %v", err)
+			b.deadLetter(s, msg, deliveryAttempt(msg), err)
+			continue
+		}
+		events = append(events, event)
+		decoded = append(decoded, msg)
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	batchCtx, cancel := context.WithTimeout(ctx, s.opts.HandlerTimeout)
+	defer cancel()
+
+	stopProgress := keepInProgress(batchCtx, s.opts.ProgressInterval, decoded...)
+	err := s.batchHandler(batchCtx, events)
+	stopProgress()
+
+	var failed BatchError
+	if !errors.As(err, &failed) {
+		for _, msg := range decoded {
+			b.settle(s, msg, err)
+		}
+		return
+	}
+	for i, msg := range decoded {
+		b.settle(s, msg, failed[i])
+	}
+}
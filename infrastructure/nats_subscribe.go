@@ -0,0 +1,283 @@
+package bsgostuff_infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	bsgostuff_events "github.com/beavernsticks/go-stuff/events"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrPoisonMessage - сообщение нельзя обработать повторно: оно сразу уходит в DLQ без повторных доставок
+var ErrPoisonMessage = errors.New("poison message")
+
+// Poison помечает ошибку обработчика как ErrPoisonMessage
+func Poison(err error) error {
+	return fmt.Errorf("%w: %w", ErrPoisonMessage, err)
+}
+
+// SubscribeOptions - параметры подписки; нулевые значения заменяются значениями по умолчанию.
+// AckWait, MaxDeliver и MaxAckPending хранятся в durable-consumer-е группы на сервере и общие для всех
+// ее экземпляров: подписка обновляет их, поэтому экземпляры группы должны использовать одинаковые значения.
+type SubscribeOptions struct {
+	// MaxInFlight - число одновременно обрабатываемых сообщений в экземпляре (по умолчанию 1)
+	MaxInFlight int
+	// MaxAckPending - сколько неподтвержденных сообщений сервер выдает группе (по умолчанию MaxInFlight);
+	// для нескольких экземпляров группы - MaxInFlight * число экземпляров
+	MaxAckPending int
+	// MaxDeliver - число доставок сообщения, после которого оно уходит в DLQ (по умолчанию 3)
+	MaxDeliver int
+	// BackOff - задержки перед повторными доставками после ошибки обработчика (Nak с задержкой);
+	// последняя задержка используется для всех следующих доставок (по умолчанию 1s, 5s, 30s)
+	BackOff []time.Duration
+	// AckWait - время до повторной доставки неподтвержденного сообщения (по умолчанию 30s)
+	AckWait time.Duration
+	// HandlerTimeout - таймаут обработчика (по умолчанию 10s)
+	HandlerTimeout time.Duration
+	// ProgressInterval - период отправки InProgress, продлевающего AckWait для долгих обработчиков
+	// (по умолчанию AckWait/2, отрицательное значение отключает)
+	ProgressInterval time.Duration
+	// IsPoison определяет ошибки, после которых повторная доставка бессмысленна
+	// (по умолчанию errors.Is(err, ErrPoisonMessage)); ошибки декодирования - всегда poison
+	IsPoison func(err error) bool
+	// DisableDLQ - не отправлять необработанные сообщения в DLQ, а только завершать (Term)
+	DisableDLQ bool
+}
+
+func (o SubscribeOptions) withDefaults() SubscribeOptions {
+	if o.MaxInFlight <= 0 {
+		o.MaxInFlight = 1
+	}
+	if o.MaxAckPending <= 0 {
+		o.MaxAckPending = o.MaxInFlight
+	}
+	if o.MaxDeliver <= 0 {
+		o.MaxDeliver = 3
+	}
+	if len(o.BackOff) == 0 {
+		o.BackOff = []time.Duration{time.Second, 5 * time.Second, 30 * time.Second}
+	}
+	if o.AckWait <= 0 {
+		o.AckWait = 30 * time.Second
+	}
+	if o.HandlerTimeout <= 0 {
+		o.HandlerTimeout = 10 * time.Second
+	}
+	if o.ProgressInterval == 0 {
+		o.ProgressInterval = o.AckWait / 2
+	}
+	if o.IsPoison == nil {
+		o.IsPoison = func(err error) bool { return errors.Is(err, ErrPoisonMessage) }
+	}
+	return o
+}
+
+// backOff возвращает задержку перед доставкой, следующей за attempt
+func (o SubscribeOptions) backOff(attempt int) time.Duration {
+	return o.BackOff[min(attempt, len(o.BackOff))-1]
+}
+
+// subscription - подписка группы на топик
+type subscription struct {
+	fullTopic string
+	dlqTopic  string
+	decode    func(*nats.Msg) (proto.Message, error)
+	handler   func(context.Context, proto.Message) error
+	// batchHandler - обработчик пачки (pull-подписка с PullSubscribeBatch)
+	batchHandler func(context.Context, []proto.Message) error
+	opts         SubscribeOptions
+}
+
+// subscribe подписывает группу на топик: decode разбирает сообщение, handler обрабатывает его.
+// Ошибка обработчика ведет к повторной доставке с задержкой BackOff, а после MaxDeliver доставок
+// или poison-ошибки сообщение отправляется в DLQ группы и завершается.
+// Durable-consumer группы - push; группа не может одновременно использовать pull-подписку (PullSubscribe).
+func (b *NATSBroker) subscribe(
+	ctx context.Context,
+	topic string,
+	queueGroup string,
+	decode func(*nats.Msg) (proto.Message, error),
+	handler func(context.Context, proto.Message) error,
+	opts SubscribeOptions,
+) error {
+	s := &subscription{
+		fullTopic: b.fullTopic(topic),
+		dlqTopic:  b.dlqTopic(queueGroup, topic),
+		decode:    decode,
+		handler:   handler,
+		opts:      opts.withDefaults(),
+	}
+
+	if !s.opts.DisableDLQ {
+		if err := b.ensureDLQStream(queueGroup); err != nil {
+			return err
+		}
+	}
+
+	durable := b.durableName(queueGroup)
+	if err := b.reconcileConsumer(ctx, s.fullTopic, durable, s.opts); err != nil {
+		return err
+	}
+
+	// Семафор ограничивает число обработчиков; при заполнении доставка ждет освобождения,
+	// а MaxAckPending не дает серверу выдать больше сообщений, чем успеют обработать до AckWait
+	inFlight := make(chan struct{}, s.opts.MaxInFlight)
+
+	sub, err := b.js.QueueSubscribe(
+		s.fullTopic,
+		queueGroup,
+		func(msg *nats.Msg) {
+			inFlight <- struct{}{}
+			go func() {
+				defer func() { <-inFlight }()
+				b.handle(ctx, s, msg)
+			}()
+		},
+		nats.Durable(durable),
+		nats.ManualAck(),
+		nats.AckWait(s.opts.AckWait),
+		nats.MaxDeliver(s.opts.MaxDeliver),
+		nats.MaxAckPending(s.opts.MaxAckPending),
+		nats.Context(ctx),
+	)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	b.subs[fmt.Sprintf("%s|%s", s.fullTopic, queueGroup)] = sub
+	b.mu.Unlock()
+
+	return nil
+}
+
+// reconcileConsumer обновляет AckWait, MaxDeliver и MaxAckPending существующего durable-consumer-а:
+// nats.go отклоняет подписку, если они отличаются от значений на сервере
+// (например, у consumer-а, созданного предыдущей версией или с другими параметрами).
+// Отсутствующий consumer создается самой подпиской.
+func (b *NATSBroker) reconcileConsumer(ctx context.Context, subject, durable string, opts SubscribeOptions) error {
+	stream, err := b.js.StreamNameBySubject(subject, nats.Context(ctx))
+	if err != nil {
+		return fmt.Errorf("stream lookup failed: %w", err)
+	}
+
+	info, err := b.js.ConsumerInfo(stream, durable, nats.Context(ctx))
+	if errors.Is(err, nats.ErrConsumerNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("consumer info failed: %w", err)
+	}
+
+	cfg := info.Config
+	if cfg.AckWait == opts.AckWait && cfg.MaxDeliver == opts.MaxDeliver && cfg.MaxAckPending == opts.MaxAckPending {
+		return nil
+	}
+	cfg.AckWait = opts.AckWait
+	cfg.MaxDeliver = opts.MaxDeliver
+	cfg.MaxAckPending = opts.MaxAckPending
+	if _, err := b.js.UpdateConsumer(stream, &cfg, nats.Context(ctx)); err != nil {
+		return fmt.Errorf("consumer update failed: %w", err)
+	}
+	return nil
+}
+
+// handle обрабатывает одну доставку сообщения и подтверждает, откладывает или завершает его
+func (b *NATSBroker) handle(ctx context.Context, s *subscription, msg *nats.Msg) {
+	event, err := s.decode(msg)
+	if err != nil {
+		log.Printf("[ERROR] unmarshal failed: %v", err)
+		b.deadLetter(s, msg, deliveryAttempt(msg), err)
+		return
+	}
+
+	msgCtx, cancel := context.WithTimeout(ctx, s.opts.HandlerTimeout)
+	defer cancel()
+
+	stopProgress := keepInProgress(msgCtx, s.opts.ProgressInterval, msg)
+	err = s.handler(msgCtx, event)
+	stopProgress()
+
+	b.settle(s, msg, err)
+}
+
+// settle подтверждает сообщение, откладывает его повторную доставку или отправляет в DLQ
+func (b *NATSBroker) settle(s *subscription, msg *nats.Msg, err error) {
+	attempt := deliveryAttempt(msg)
+
+	switch {
+	case err == nil:
+		_ = msg.Ack()
+	case !s.opts.IsPoison(err) && attempt < s.opts.MaxDeliver:
+		delay := s.opts.backOff(attempt)
+		log.Printf("[WARN] handler failed (attempt %d/%d), redelivering in %s: %v", attempt, s.opts.MaxDeliver, delay, err)
+		_ = msg.NakWithDelay(delay)
+	default:
+		log.Printf("[WARN] handler failed (attempt %d/%d), sending to DLQ: %v", attempt, s.opts.MaxDeliver, err)
+		b.deadLetter(s, msg, attempt, err)
+	}
+}
+
+// deliveryAttempt возвращает номер доставки сообщения JetStream
+func deliveryAttempt(msg *nats.Msg) int {
+	if meta, err := msg.Metadata(); err == nil {
+		return int(meta.NumDelivered)
+	}
+	return 1
+}
+
+// deadLetter отправляет сообщение в DLQ (если он включен) и завершает его доставку
+func (b *NATSBroker) deadLetter(s *subscription, msg *nats.Msg, attempt int, cause error) {
+	if !s.opts.DisableDLQ {
+		letter, _ := proto.Marshal(&bsgostuff_events.DeadLetter{
+			OriginalTopic: s.fullTopic,
+			Payload:       msg.Data,
+			Error:         cause.Error(),
+			Attempt:       int32(attempt),
+			Timestamp:     time.Now().Format(time.RFC3339),
+			ContentType:   msg.Header.Get(ContentTypeHeader),
+		})
+		if err := b.publish(context.Background(), s.dlqTopic, letter); err != nil {
+			log.Printf("[ERROR] DLQ publish failed: %v", err)
+		}
+	}
+	_ = msg.Term()
+}
+
+// keepInProgress периодически продлевает AckWait сообщений, пока обработчик работает;
+// возвращает функцию остановки
+func keepInProgress(ctx context.Context, interval time.Duration, msgs ...*nats.Msg) func() {
+	if interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for _, msg := range msgs {
+					_ = msg.InProgress()
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
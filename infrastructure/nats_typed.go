@@ -0,0 +1,240 @@
+package bsgostuff_infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Заголовки NATS, описывающие содержимое сообщения
+const (
+	// ContentTypeHeader - кодек сообщения (Codec.ContentType)
+	ContentTypeHeader = "Content-Type"
+	// MessageTypeHeader - полное имя proto-сообщения
+	MessageTypeHeader = "Message-Type"
+)
+
+// Codec сериализует proto-сообщения для NATS
+type Codec interface {
+	// ContentType - значение заголовка Content-Type
+	ContentType() string
+	Marshal(msg proto.Message) ([]byte, error)
+	Unmarshal(data []byte, msg proto.Message) error
+}
+
+var (
+	// ProtoCodec - двоичный protobuf (формат сообщений без заголовка Content-Type)
+	ProtoCodec Codec = protoCodec{}
+	// ProtoJSONCodec - каноническое JSON-представление protobuf
+	ProtoJSONCodec Codec = protoJSONCodec{}
+	// JSONCodec - encoding/json по тегам json сгенерированных структур.
+	// encoding/json не декодирует oneof (поля-интерфейсы), поэтому типы с oneof
+	// (в том числе во вложенных сообщениях) NewTopic отклоняет - для них нужен ProtoJSONCodec
+	JSONCodec Codec = jsonCodec{}
+)
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{
+		ProtoCodec.ContentType():     ProtoCodec,
+		ProtoJSONCodec.ContentType(): ProtoJSONCodec,
+		JSONCodec.ContentType():      JSONCodec,
+	}
+)
+
+// RegisterCodec регистрирует кодек, чтобы подписчики могли декодировать сообщения с его Content-Type
+func RegisterCodec(codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[codec.ContentType()] = codec
+}
+
+// codecFor возвращает кодек по Content-Type; пустой Content-Type - fallback
+func codecFor(contentType string, fallback Codec) (Codec, error) {
+	if contentType == "" {
+		return fallback, nil
+	}
+
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	codec, ok := codecs[contentType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported content type %q", contentType)
+	}
+	return codec, nil
+}
+
+// decodeMsg декодирует сообщение NATS в event кодеком из заголовков
+func decodeMsg(msg *nats.Msg, fallback Codec, event proto.Message) error {
+	if messageType := msg.Header.Get(MessageTypeHeader); messageType != "" {
+		if expected := string(event.ProtoReflect().Descriptor().FullName()); messageType != expected {
+			return fmt.Errorf("message type %q does not match %q", messageType, expected)
+		}
+	}
+
+	codec, err := codecFor(msg.Header.Get(ContentTypeHeader), fallback)
+	if err != nil {
+		return err
+	}
+	if err := codec.Unmarshal(msg.Data, event); err != nil {
+		return fmt.Errorf("%s unmarshal failed: %w", codec.ContentType(), err)
+	}
+	return nil
+}
+
+// messageChecker - необязательный интерфейс кодека, который поддерживает не все типы сообщений
+type messageChecker interface {
+	CheckMessage(desc protoreflect.MessageDescriptor) error
+}
+
+// Topic связывает топик с типом сообщения T и кодеком публикации
+type Topic[T proto.Message] struct {
+	subject string
+	codec   Codec
+}
+
+// NewTopic описывает топик; codec == nil - ProtoCodec.
+// Возвращает ошибку, если кодек не поддерживает тип T (например, JSONCodec и oneof).
+func NewTopic[T proto.Message](subject string, codec Codec) (Topic[T], error) {
+	if codec == nil {
+		codec = ProtoCodec
+	}
+
+	topic := Topic[T]{subject: subject, codec: codec}
+	if checker, ok := codec.(messageChecker); ok {
+		if err := checker.CheckMessage(topic.New().ProtoReflect().Descriptor()); err != nil {
+			return topic, fmt.Errorf("topic %s: %w", subject, err)
+		}
+	}
+	return topic, nil
+}
+
+// MustNewTopic описывает топик или паникует при ошибке
+func MustNewTopic[T proto.Message](subject string, codec Codec) Topic[T] {
+	topic, err := NewTopic[T](subject, codec)
+	if err != nil {
+		panic(fmt.Errorf("failed to initialize topic: %w", err))
+	}
+	return topic
+}
+
+// Subject возвращает топик без префикса окружения
+func (t Topic[T]) Subject() string {
+	return t.subject
+}
+
+// Codec возвращает кодек публикации
+func (t Topic[T]) Codec() Codec {
+	return t.codec
+}
+
+// New создает пустое сообщение типа T
+func (t Topic[T]) New() T {
+	var zero T
+	return zero.ProtoReflect().Type().New().Interface().(T)
+}
+
+// decode декодирует сообщение NATS в новое сообщение типа T
+func (t Topic[T]) decode(msg *nats.Msg) (proto.Message, error) {
+	event := t.New()
+	return event, decodeMsg(msg, t.codec, event)
+}
+
+// Publish публикует сообщение в топик кодеком топика; кодек и тип передаются в заголовках
+func Publish[T proto.Message](ctx context.Context, b *NATSBroker, topic Topic[T], msg T, opts ...nats.PubOpt) error {
+	payload, err := topic.codec.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("%s marshal failed: %w", topic.codec.ContentType(), err)
+	}
+
+	return b.publishMsg(ctx, &nats.Msg{
+		Subject: b.fullTopic(topic.subject),
+		Data:    payload,
+		Header: nats.Header{
+			ContentTypeHeader: []string{topic.codec.ContentType()},
+			MessageTypeHeader: []string{string(msg.ProtoReflect().Descriptor().FullName())},
+		},
+	}, opts...)
+}
+
+// Subscribe подписывает группу на топик с типизированным обработчиком и параметрами по умолчанию
+func Subscribe[T proto.Message](ctx context.Context, b *NATSBroker, topic Topic[T], queueGroup string, handler func(context.Context, T) error) error {
+	return SubscribeWithOptions(ctx, b, topic, queueGroup, handler, SubscribeOptions{})
+}
+
+// SubscribeWithOptions подписывает группу на топик с типизированным обработчиком.
+// Кодек выбирается по заголовку Content-Type; без заголовка - кодек топика.
+func SubscribeWithOptions[T proto.Message](ctx context.Context, b *NATSBroker, topic Topic[T], queueGroup string, handler func(context.Context, T) error, opts SubscribeOptions) error {
+	return b.subscribe(ctx, topic.subject, queueGroup, topic.decode, func(ctx context.Context, event proto.Message) error {
+		return handler(ctx, event.(T))
+	}, opts)
+}
+
+type protoCodec struct{}
+
+func (protoCodec) ContentType() string { return "application/protobuf" }
+
+func (protoCodec) Marshal(msg proto.Message) ([]byte, error) { return proto.Marshal(msg) }
+
+func (protoCodec) Unmarshal(data []byte, msg proto.Message) error { return proto.Unmarshal(data, msg) }
+
+type protoJSONCodec struct{}
+
+func (protoJSONCodec) ContentType() string { return "application/protobuf+json" }
+
+func (protoJSONCodec) Marshal(msg proto.Message) ([]byte, error) { return protojson.Marshal(msg) }
+
+func (protoJSONCodec) Unmarshal(data []byte, msg proto.Message) error {
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, msg)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string { return "application/json" }
+
+func (jsonCodec) Marshal(msg proto.Message) ([]byte, error) { return json.Marshal(msg) }
+
+func (jsonCodec) Unmarshal(data []byte, msg proto.Message) error {
+	proto.Reset(msg)
+	return json.Unmarshal(data, msg)
+}
+
+// CheckMessage отклоняет сообщения с oneof: encoding/json не может их декодировать
+func (jsonCodec) CheckMessage(desc protoreflect.MessageDescriptor) error {
+	return checkNoOneof(desc, make(map[protoreflect.FullName]bool))
+}
+
+// checkNoOneof ищет oneof в сообщении и вложенных сообщениях (optional proto3 не считается)
+func checkNoOneof(desc protoreflect.MessageDescriptor, visited map[protoreflect.FullName]bool) error {
+	if visited[desc.FullName()] {
+		return nil
+	}
+	visited[desc.FullName()] = true
+
+	oneofs := desc.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		if oneof := oneofs.Get(i); !oneof.IsSynthetic() {
+			return fmt.Errorf("%s: oneof %s is not supported by %s, use %s", desc.FullName(), oneof.Name(), JSONCodec.ContentType(), ProtoJSONCodec.ContentType())
+		}
+	}
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.IsMap() {
+			field = field.MapValue()
+		}
+		if field.Message() != nil {
+			if err := checkNoOneof(field.Message(), visited); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,373 @@
+package bsgostuff_infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	bsgostuff_types "github.com/beavernsticks/go-stuff/types"
+	"github.com/jackc/pgx/v5"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultOutboxTable - имя таблицы outbox по умолчанию
+const DefaultOutboxTable = "outbox"
+
+// OutboxTableSQL возвращает DDL таблицы outbox (для миграций)
+func OutboxTableSQL(table string) string {
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+	id              BIGSERIAL PRIMARY KEY,
+	topic           TEXT        NOT NULL,
+	message_type    TEXT        NOT NULL,
+	payload         BYTEA       NOT NULL,
+	attempts        INTEGER     NOT NULL DEFAULT 0,
+	last_error      TEXT,
+	created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+	next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	sent_at         TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS %[2]s_pending_idx ON %[1]s (next_attempt_at) WHERE sent_at IS NULL;
+CREATE INDEX IF NOT EXISTS %[2]s_pending_topic_idx ON %[1]s (topic, id) WHERE sent_at IS NULL;`, table, sanitizeIdentifier(table))
+}
+
+// Outbox записывает события в таблицу outbox в транзакции из контекста (см. TxManager),
+// чтобы событие сохранялось атомарно вместе с изменениями данных
+type Outbox struct {
+	db    Querier
+	table string
+}
+
+func NewOutbox(db Querier, table string) *Outbox {
+	if table == "" {
+		table = DefaultOutboxTable
+	}
+	return &Outbox{db: db, table: table}
+}
+
+// Add сохраняет событие для последующей публикации в topic.
+// Должен вызываться внутри TxManager.Do, иначе запись не будет атомарной с остальными изменениями.
+func (o *Outbox) Add(ctx context.Context, topic string, msg proto.Message) error {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("proto marshal failed: %w", err)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (topic, message_type, payload) VALUES ($1, $2, $3)", o.table)
+	if _, err := QuerierFromContext(ctx, o.db).Exec(ctx, query, topic, string(msg.ProtoReflect().Descriptor().FullName()), payload); err != nil {
+		return fmt.Errorf("outbox insert failed: %w", err)
+	}
+
+	return nil
+}
+
+// OutboxPublisher - получатель событий из outbox (реализуется NATSBroker)
+type OutboxPublisher interface {
+	PublishRaw(ctx context.Context, topic string, payload []byte, opts ...nats.PubOpt) error
+}
+
+// OutboxDB - соединение, которое нужно relay для выборки и отметки событий
+type OutboxDB interface {
+	Querier
+	TxBeginner
+}
+
+// OutboxRelayConfig - параметры relay
+type OutboxRelayConfig struct {
+	Table        string
+	BatchSize    int
+	PollInterval time.Duration
+	// MaxAttempts - после стольких неудачных попыток событие больше не публикуется
+	MaxAttempts int
+	// RetryDelay и MaxRetryDelay задают экспоненциальную задержку между попытками
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+}
+
+// OutboxStats - метрики outbox
+type OutboxStats struct {
+	// Pending - события, ожидающие публикации
+	Pending int64
+	// Exhausted - события, исчерпавшие попытки публикации
+	Exhausted int64
+	// Lag - возраст самого старого неопубликованного события
+	Lag time.Duration
+	// Published и Failed - счетчики relay с момента запуска
+	Published uint64
+	Failed    uint64
+	// LastPollAt - время последней выборки событий
+	LastPollAt time.Time
+}
+
+// OutboxRelay публикует события из outbox через OutboxPublisher.
+// Несколько экземпляров могут работать параллельно благодаря FOR UPDATE SKIP LOCKED.
+// Доставка "как минимум один раз": JetStream отбрасывает дубли по Nats-Msg-Id.
+// События одного топика публикуются по порядку и при нескольких экземплярах: пачка публикует события
+// топика, только если начинается с его самого раннего неотправленного события, а после ошибки следующие
+// события топика ждут повторной попытки предыдущего (пока оно не исчерпает MaxAttempts).
+type OutboxRelay struct {
+	db        OutboxDB
+	publisher OutboxPublisher
+	cfg       OutboxRelayConfig
+
+	published  atomic.Uint64
+	failed     atomic.Uint64
+	mu         sync.Mutex
+	lastPollAt time.Time
+}
+
+func NewOutboxRelay(db OutboxDB, publisher OutboxPublisher, cfg OutboxRelayConfig) *OutboxRelay {
+	if cfg.Table == "" {
+		cfg.Table = DefaultOutboxTable
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 10
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = time.Second
+	}
+	if cfg.MaxRetryDelay <= 0 {
+		cfg.MaxRetryDelay = 5 * time.Minute
+	}
+
+	return &OutboxRelay{db: db, publisher: publisher, cfg: cfg}
+}
+
+// Run публикует события, пока не будет отменен контекст
+func (r *OutboxRelay) Run(ctx context.Context) error {
+	for {
+		processed, err := r.ProcessBatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "outbox relay batch failed", slog.Any("error", err))
+		}
+
+		// Полная пачка - вероятно, есть еще события, продолжаем без паузы
+		if err == nil && processed == r.cfg.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(r.cfg.PollInterval):
+		}
+	}
+}
+
+// outboxEvent - строка outbox
+type outboxEvent struct {
+	id       int64
+	topic    string
+	payload  []byte
+	attempts int
+}
+
+// ProcessBatch публикует одну пачку готовых к отправке событий.
+// Возвращает число выбранных событий (опубликованных, неудачных и отложенных из-за ошибки в топике);
+// события топиков, которые публикует другой экземпляр, не учитываются.
+func (r *OutboxRelay) ProcessBatch(ctx context.Context) (int, error) {
+	r.mu.Lock()
+	r.lastPollAt = time.Now()
+	r.mu.Unlock()
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("begin outbox transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+
+	events, err := r.lockPending(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	if events, err = r.dropOvertaking(ctx, tx, events); err != nil {
+		return 0, err
+	}
+
+	sent := make([]int64, 0, len(events))
+	// failedTopics - топики с неудачной публикацией: их следующие события пачки не публикуются
+	failedTopics := make(map[string]struct{})
+	for _, event := range events {
+		if _, failed := failedTopics[event.topic]; failed {
+			continue
+		}
+
+		publishErr := r.publisher.PublishRaw(ctx, event.topic, event.payload, nats.MsgId(fmt.Sprintf("%s-%d", r.cfg.Table, event.id)))
+		if publishErr == nil {
+			sent = append(sent, event.id)
+			continue
+		}
+
+		r.failed.Add(1)
+		failedTopics[event.topic] = struct{}{}
+		slog.WarnContext(ctx, "outbox publish failed",
+			slog.Int64("id", event.id),
+			slog.String("topic", event.topic),
+			slog.Int("attempt", event.attempts+1),
+			slog.Any("error", publishErr),
+		)
+
+		query := fmt.Sprintf("UPDATE %s SET attempts = attempts + 1, last_error = $2, next_attempt_at = now() + $3::interval WHERE id = $1", r.cfg.Table)
+		if _, err := tx.Exec(ctx, query, event.id, publishErr.Error(), bsgostuff_types.NewDuration(r.retryDelay(event.attempts+1))); err != nil {
+			return 0, fmt.Errorf("outbox mark failed: %w", err)
+		}
+	}
+
+	if len(sent) > 0 {
+		query := fmt.Sprintf("UPDATE %s SET sent_at = now(), attempts = attempts + 1, last_error = NULL WHERE id = ANY($1)", r.cfg.Table)
+		if _, err := tx.Exec(ctx, query, sent); err != nil {
+			return 0, fmt.Errorf("outbox mark sent: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit outbox transaction: %w", err)
+	}
+	r.published.Add(uint64(len(sent)))
+
+	return len(events), nil
+}
+
+// lockPending выбирает и блокирует готовые к отправке события.
+// События топика, у которого более раннее событие ждет повторной попытки, не выбираются.
+func (r *OutboxRelay) lockPending(ctx context.Context, tx pgx.Tx) ([]outboxEvent, error) {
+	query := fmt.Sprintf(`SELECT id, topic, payload, attempts FROM %[1]s AS event
+WHERE sent_at IS NULL AND next_attempt_at <= now() AND attempts < $1
+	AND NOT EXISTS (
+		SELECT 1 FROM %[1]s AS earlier
+		WHERE earlier.topic = event.topic AND earlier.id < event.id
+			AND earlier.sent_at IS NULL AND earlier.attempts < $1 AND earlier.next_attempt_at > now()
+	)
+ORDER BY id
+LIMIT $2
+FOR UPDATE SKIP LOCKED`, r.cfg.Table)
+
+	rows, err := tx.Query(ctx, query, r.cfg.MaxAttempts, r.cfg.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("outbox select failed: %w", err)
+	}
+
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (outboxEvent, error) {
+		var event outboxEvent
+		err := row.Scan(&event.id, &event.topic, &event.payload, &event.attempts)
+		return event, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("outbox scan failed: %w", err)
+	}
+
+	return events, nil
+}
+
+// dropOvertaking исключает события топиков, у которых есть более раннее неотправленное событие вне пачки:
+// его держит другой экземпляр relay (SKIP LOCKED), и публикация пачки обогнала бы его
+func (r *OutboxRelay) dropOvertaking(ctx context.Context, tx pgx.Tx, events []outboxEvent) ([]outboxEvent, error) {
+	// События выбраны по возрастанию id, поэтому первое событие топика - самое раннее в пачке
+	first := make(map[string]int64)
+	topics := make([]string, 0)
+	for _, event := range events {
+		if _, ok := first[event.topic]; !ok {
+			first[event.topic] = event.id
+			topics = append(topics, event.topic)
+		}
+	}
+	if len(topics) == 0 {
+		return events, nil
+	}
+
+	query := fmt.Sprintf(`SELECT topic, min(id) FROM %s
+WHERE sent_at IS NULL AND attempts < $1 AND topic = ANY($2)
+GROUP BY topic`, r.cfg.Table)
+	rows, err := tx.Query(ctx, query, r.cfg.MaxAttempts, topics)
+	if err != nil {
+		return nil, fmt.Errorf("outbox order check failed: %w", err)
+	}
+
+	blocked := make(map[string]bool)
+	var topic string
+	var earliest int64
+	_, err = pgx.ForEachRow(rows, []any{&topic, &earliest}, func() error {
+		blocked[topic] = earliest < first[topic]
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("outbox order check failed: %w", err)
+	}
+
+	kept := events[:0]
+	for _, event := range events {
+		if !blocked[event.topic] {
+			kept = append(kept, event)
+		}
+	}
+	return kept, nil
+}
+
+// retryDelay возвращает задержку перед следующей попыткой
+func (r *OutboxRelay) retryDelay(attempt int) time.Duration {
+	delay := r.cfg.RetryDelay
+	for i := 1; i < attempt && delay < r.cfg.MaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > r.cfg.MaxRetryDelay {
+		return r.cfg.MaxRetryDelay
+	}
+	return delay
+}
+
+// Stats возвращает метрики outbox, включая лаг публикации
+func (r *OutboxRelay) Stats(ctx context.Context) (OutboxStats, error) {
+	stats := OutboxStats{
+		Published: r.published.Load(),
+		Failed:    r.failed.Load(),
+	}
+	r.mu.Lock()
+	stats.LastPollAt = r.lastPollAt
+	r.mu.Unlock()
+
+	query := fmt.Sprintf(`SELECT
+	count(*) FILTER (WHERE attempts < $1),
+	count(*) FILTER (WHERE attempts >= $1),
+	COALESCE(EXTRACT(EPOCH FROM now() - min(created_at) FILTER (WHERE attempts < $1)), 0)::float8
+FROM %s WHERE sent_at IS NULL`, r.cfg.Table)
+
+	var lagSeconds float64
+	if err := r.db.QueryRow(ctx, query, r.cfg.MaxAttempts).Scan(&stats.Pending, &stats.Exhausted, &lagSeconds); err != nil {
+		return stats, fmt.Errorf("outbox stats failed: %w", err)
+	}
+	stats.Lag = time.Duration(lagSeconds * float64(time.Second))
+
+	return stats, nil
+}
+
+// Cleanup удаляет опубликованные события старше olderThan
+func (r *OutboxRelay) Cleanup(ctx context.Context, olderThan time.Duration) (int64, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE sent_at IS NOT NULL AND sent_at < now() - $1::interval", r.cfg.Table)
+	tag, err := r.db.Exec(ctx, query, bsgostuff_types.NewDuration(olderThan))
+	if err != nil {
+		return 0, fmt.Errorf("outbox cleanup failed: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// sanitizeIdentifier превращает имя таблицы (возможно, со схемой) в часть имени индекса
+func sanitizeIdentifier(name string) string {
+	out := []rune(name)
+	for i, r := range out {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			out[i] = '_'
+		}
+	}
+	return string(out)
+}
+
+var _ OutboxPublisher = (*NATSBroker)(nil)
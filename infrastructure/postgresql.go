@@ -3,8 +3,13 @@ package bsgostuff_infrastructure
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
 
 	bsgostuff_config "github.com/beavernsticks/go-stuff/config"
+	bsgostuff_types "github.com/beavernsticks/go-stuff/types"
 	pgxuuid "github.com/jackc/pgx-gofrs-uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -13,40 +18,176 @@ import (
 
 // Return new Postgresql db instance
 func NewPostgresDB(config bsgostuff_config.PostgreSQL) (*pgxpool.Pool, error) {
-	dataSourceName := config.ConnectionString
+	return NewPostgresPoolBuilder(config).Build(context.Background())
+}
 
+// MustNewPostgresDB создает адаптер или паникует при ошибке
+func MustNewPostgresDB(cfg bsgostuff_config.PostgreSQL) *pgxpool.Pool {
+	pool, err := NewPostgresDB(cfg)
+	if err != nil {
+		panic(fmt.Errorf("failed to initialize PostgreSQL connection: %w", err))
+	}
+	return pool
+}
+
+// PostgresPoolBuilder собирает pgxpool.Config из конфигурации и хуков.
+// На каждом новом соединении регистрируются типы UUID и bsgostuff_types,
+// затем выполняются хуки AfterConnect в порядке добавления.
+type PostgresPoolBuilder struct {
+	cfg          bsgostuff_config.PostgreSQL
+	afterConnect []func(ctx context.Context, conn *pgx.Conn) error
+	configure    []func(cfg *pgxpool.Config)
+}
+
+func NewPostgresPoolBuilder(cfg bsgostuff_config.PostgreSQL) *PostgresPoolBuilder {
+	return &PostgresPoolBuilder{cfg: cfg}
+}
+
+// AfterConnect добавляет хук, выполняемый на каждом новом соединении
+func (b *PostgresPoolBuilder) AfterConnect(hook func(ctx context.Context, conn *pgx.Conn) error) *PostgresPoolBuilder {
+	b.afterConnect = append(b.afterConnect, hook)
+	return b
+}
+
+// Configure добавляет произвольную настройку pgxpool.Config (трейсер, BeforeAcquire и т.п.).
+// Выполняется после применения конфигурации; AfterConnect следует задавать через AfterConnect.
+func (b *PostgresPoolBuilder) Configure(fn func(cfg *pgxpool.Config)) *PostgresPoolBuilder {
+	b.configure = append(b.configure, fn)
+	return b
+}
+
+// Config возвращает конфигурацию пула.
+// Если задан ConnectionString, он используется как есть (включая TLS),
+// а параметры пула и сессии применяются поверх него.
+func (b *PostgresPoolBuilder) Config() (*pgxpool.Config, error) {
+	dataSourceName := b.cfg.ConnectionString
 	if dataSourceName == "" {
-		dataSourceName = fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=disable password=%s",
-			config.Host,
-			config.Port,
-			config.User,
-			config.DBName,
-			config.Password,
-		)
+		dataSourceName = b.dataSourceName()
 	}
 
-	pool, err := pgxpool.New(context.Background(), dataSourceName)
+	poolConfig, err := pgxpool.ParseConfig(dataSourceName)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("parse PostgreSQL config: %w", err)
+	}
+
+	if b.cfg.MinConns > 0 {
+		poolConfig.MinConns = b.cfg.MinConns
+	}
+	if b.cfg.MaxConns > 0 {
+		poolConfig.MaxConns = b.cfg.MaxConns
+	}
+	if b.cfg.MaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = b.cfg.MaxConnLifetime
+	}
+	if b.cfg.MaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = b.cfg.MaxConnIdleTime
+	}
+	if b.cfg.HealthCheckPeriod > 0 {
+		poolConfig.HealthCheckPeriod = b.cfg.HealthCheckPeriod
 	}
 
-	pool.Config().AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+	runtimeParams := poolConfig.ConnConfig.RuntimeParams
+	if b.cfg.StatementTimeout > 0 {
+		runtimeParams["statement_timeout"] = strconv.FormatInt(b.cfg.StatementTimeout.Milliseconds(), 10)
+	}
+	if b.cfg.ApplicationName != "" {
+		runtimeParams["application_name"] = b.cfg.ApplicationName
+	}
+	if b.cfg.SearchPath != "" {
+		runtimeParams["search_path"] = b.cfg.SearchPath
+	}
+
+	hooks := b.afterConnect
+	poolConfig.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
 		pgxuuid.Register(conn.TypeMap())
+		bsgostuff_types.RegisterPgTypes(conn.TypeMap())
+
+		for _, hook := range hooks {
+			if err := hook(ctx, conn); err != nil {
+				return err
+			}
+		}
 		return nil
 	}
 
-	if err = pool.Ping(context.Background()); err != nil {
-		return nil, err
+	for _, fn := range b.configure {
+		fn(poolConfig)
 	}
 
-	return pool, nil
+	return poolConfig, nil
 }
 
-// MustNewPostgresDB создает адаптер или паникует при ошибке
-func MustNewPostgresDB(cfg bsgostuff_config.PostgreSQL) *pgxpool.Pool {
-	pool, err := NewPostgresDB(cfg)
+// Build создает пул и проверяет подключение, повторяя попытки ConnectRetries раз
+func (b *PostgresPoolBuilder) Build(ctx context.Context) (*pgxpool.Pool, error) {
+	poolConfig, err := b.Config()
 	if err != nil {
-		panic(fmt.Errorf("failed to initialize PostgreSQL connection: %w", err))
+		return nil, err
 	}
-	return pool
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	delay := b.cfg.ConnectRetryDelay
+	if delay <= 0 {
+		delay = time.Second
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = pool.Ping(ctx)
+		if err == nil {
+			return pool, nil
+		}
+		if attempt >= b.cfg.ConnectRetries {
+			break
+		}
+
+		slog.WarnContext(ctx, "PostgreSQL connection failed, retrying",
+			slog.Int("attempt", attempt+1),
+			slog.Duration("delay", delay),
+			slog.Any("error", err),
+		)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			pool.Close()
+			return nil, ctx.Err()
+		}
+		delay *= 2
+	}
+
+	pool.Close()
+	return nil, err
+}
+
+// dataSourceName собирает строку подключения в формате key=value
+func (b *PostgresPoolBuilder) dataSourceName() string {
+	sslMode := b.cfg.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+
+	params := []struct{ key, value string }{
+		{"host", b.cfg.Host},
+		{"port", b.cfg.Port},
+		{"user", b.cfg.User},
+		{"dbname", b.cfg.DBName},
+		{"password", b.cfg.Password},
+		{"sslmode", sslMode},
+		{"sslrootcert", b.cfg.SSLRootCert},
+		{"sslcert", b.cfg.SSLCert},
+		{"sslkey", b.cfg.SSLKey},
+	}
+
+	parts := make([]string, 0, len(params))
+	for _, param := range params {
+		if param.value == "" {
+			continue
+		}
+		value := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(param.value)
+		parts = append(parts, fmt.Sprintf("%s='%s'", param.key, value))
+	}
+	return strings.Join(parts, " ")
 }
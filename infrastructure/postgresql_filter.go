@@ -0,0 +1,100 @@
+package bsgostuff_infrastructure
+
+import (
+	"fmt"
+	"strings"
+
+	bsgostuff_domain "github.com/beavernsticks/go-stuff/domain"
+)
+
+// filterOperators - SQL-операторы условий с одним значением
+var filterOperators = map[bsgostuff_domain.FilterOperatorEnum]string{
+	bsgostuff_domain.FilterOperatorEnumEq:    "=",
+	bsgostuff_domain.FilterOperatorEnumNe:    "<>",
+	bsgostuff_domain.FilterOperatorEnumLt:    "<",
+	bsgostuff_domain.FilterOperatorEnumLte:   "<=",
+	bsgostuff_domain.FilterOperatorEnumGt:    ">",
+	bsgostuff_domain.FilterOperatorEnumGte:   ">=",
+	bsgostuff_domain.FilterOperatorEnumLike:  "LIKE",
+	bsgostuff_domain.FilterOperatorEnumIlike: "ILIKE",
+}
+
+// FilterSQL переводит фильтр в параметризованное условие для WHERE.
+// Фильтр проверяется по белому списку fields (см. Filter.Validate); в SQL попадают
+// только выражения из списка, значения приводятся к типам полей и передаются
+// параметрами $argOffset+1... Пустой фильтр дает TRUE.
+func FilterSQL(filter bsgostuff_domain.Filter, fields bsgostuff_domain.FilterFields, argOffset int) (string, []any, error) {
+	if err := filter.Validate(fields); err != nil {
+		return "", nil, err
+	}
+
+	builder := &filterBuilder{fields: fields, argOffset: argOffset}
+	condition, err := builder.build(filter)
+	if err != nil {
+		return "", nil, err
+	}
+	return condition, builder.args, nil
+}
+
+type filterBuilder struct {
+	fields    bsgostuff_domain.FilterFields
+	argOffset int
+	args      []any
+}
+
+func (b *filterBuilder) build(filter bsgostuff_domain.Filter) (string, error) {
+	switch {
+	case len(filter.And) > 0:
+		return b.join(filter.And, " AND ")
+	case len(filter.Or) > 0:
+		return b.join(filter.Or, " OR ")
+	case filter.Not != nil:
+		condition, err := b.build(*filter.Not)
+		if err != nil {
+			return "", err
+		}
+		return "NOT " + condition, nil
+	case filter.Field.GetValue() == "":
+		return "TRUE", nil
+	}
+
+	field := b.fields[filter.Field.GetValue()]
+	operator := filter.Operator.GetValue()
+
+	placeholders := make([]string, len(filter.Values))
+	for i, raw := range filter.Values {
+		value := field.NewValue()
+		if err := value.Set(raw); err != nil {
+			return "", fmt.Errorf("%w: filter value for %q: %w", bsgostuff_domain.ErrInvalidArgument, filter.Field.GetValue(), err)
+		}
+		b.args = append(b.args, value)
+		placeholders[i] = fmt.Sprintf("$%d", b.argOffset+len(b.args))
+	}
+
+	switch operator {
+	case bsgostuff_domain.FilterOperatorEnumIsNull:
+		return fmt.Sprintf("(%s IS NULL)", field.Expression), nil
+	case bsgostuff_domain.FilterOperatorEnumIsNotNull:
+		return fmt.Sprintf("(%s IS NOT NULL)", field.Expression), nil
+	case bsgostuff_domain.FilterOperatorEnumIn:
+		return fmt.Sprintf("(%s IN (%s))", field.Expression, strings.Join(placeholders, ", ")), nil
+	case bsgostuff_domain.FilterOperatorEnumNotIn:
+		return fmt.Sprintf("(%s NOT IN (%s))", field.Expression, strings.Join(placeholders, ", ")), nil
+	case bsgostuff_domain.FilterOperatorEnumBetween:
+		return fmt.Sprintf("(%s BETWEEN %s AND %s)", field.Expression, placeholders[0], placeholders[1]), nil
+	default:
+		return fmt.Sprintf("(%s %s %s)", field.Expression, filterOperators[operator], placeholders[0]), nil
+	}
+}
+
+func (b *filterBuilder) join(filters []bsgostuff_domain.Filter, separator string) (string, error) {
+	conditions := make([]string, len(filters))
+	for i, filter := range filters {
+		condition, err := b.build(filter)
+		if err != nil {
+			return "", err
+		}
+		conditions[i] = condition
+	}
+	return "(" + strings.Join(conditions, separator) + ")", nil
+}
@@ -0,0 +1,145 @@
+package bsgostuff_infrastructure
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	bsgostuff_domain "github.com/beavernsticks/go-stuff/domain"
+)
+
+// KeysetPredicate строит условие "строка после курсора" для сортировки по keys
+// (последний ключ - уникальный тай-брейкер, обычно id, и не может быть NULL).
+// Имена колонок подставляются в SQL как есть и должны браться из белого списка,
+// а не из курсора клиента (см. Cursor.Matches). Значения передаются параметрами
+// $argOffset+1... в текстовом виде; PostgreSQL приводит их к типам колонок.
+//
+// При одинаковом направлении и заданных значениях используется сравнение строк
+// (a, id) > ($1, $2), которое может использовать составной индекс; иначе - развернутое
+// условие a > $1 OR (a = $1 AND id < $2). NULL учитываются по положению в ORDER BY (CursorKey.Nulls):
+// при NULLS LAST добавляется ветка a IS NULL, а NULL в курсоре сравнивается через IS NULL.
+func KeysetPredicate(keys []bsgostuff_domain.CursorKey, argOffset int) (string, []any, error) {
+	if len(keys) == 0 {
+		return "", nil, fmt.Errorf("%w: keyset requires at least one key", bsgostuff_domain.ErrInvalidArgument)
+	}
+	if last := keys[len(keys)-1]; last.Value == nil {
+		return "", nil, bsgostuff_domain.NewInvalidArgumentError(bsgostuff_domain.FieldViolation{
+			Field: "cursor", Code: bsgostuff_domain.ViolationInvalid, Message: fmt.Sprintf("keyset tiebreaker %s is null", last.Column),
+		})
+	}
+
+	args := make([]any, 0, len(keys))
+	placeholders := make([]string, len(keys))
+	uniform := true
+	for i, key := range keys {
+		if key.Value != nil {
+			args = append(args, *key.Value)
+			placeholders[i] = fmt.Sprintf("$%d", argOffset+len(args))
+		}
+		uniform = uniform && key.Value != nil && key.Desc == keys[0].Desc
+	}
+
+	// equal - условие "значение ключа i равно значению курсора"
+	equal := func(i int) string {
+		if keys[i].Value == nil {
+			return keys[i].Column + " IS NULL"
+		}
+		return fmt.Sprintf("%s = %s", keys[i].Column, placeholders[i])
+	}
+
+	branches := make([]string, 0, len(keys)+1)
+	if uniform {
+		columns := make([]string, len(keys))
+		for i, key := range keys {
+			columns[i] = key.Column
+		}
+		branches = append(branches, fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), keysetOperator(keys[0]), strings.Join(placeholders, ", ")))
+	}
+
+	for i, key := range keys {
+		// after - условия "значение ключа i после значения курсора"
+		after := make([]string, 0, 2)
+		switch {
+		case key.Value == nil && keysetNullsFirst(key):
+			after = append(after, key.Column+" IS NOT NULL")
+		case key.Value == nil:
+			// NULLS LAST: после NULL значений нет
+		default:
+			if !uniform {
+				after = append(after, fmt.Sprintf("%s %s %s", key.Column, keysetOperator(key), placeholders[i]))
+			}
+			if !keysetNullsFirst(key) && i < len(keys)-1 {
+				after = append(after, key.Column+" IS NULL")
+			}
+		}
+
+		for _, condition := range after {
+			conditions := make([]string, 0, i+1)
+			for j := range i {
+				conditions = append(conditions, equal(j))
+			}
+			conditions = append(conditions, condition)
+			branches = append(branches, "("+strings.Join(conditions, " AND ")+")")
+		}
+	}
+
+	if len(branches) == 1 {
+		return branches[0], args, nil
+	}
+	return "(" + strings.Join(branches, " OR ") + ")", args, nil
+}
+
+func keysetOperator(key bsgostuff_domain.CursorKey) string {
+	if key.Desc {
+		return "<"
+	}
+	return ">"
+}
+
+// keysetNullsFirst сообщает, что NULL ключа идут перед значениями: по Nulls,
+// а без него - как в PostgreSQL (при DESC)
+func keysetNullsFirst(key bsgostuff_domain.CursorKey) bool {
+	switch key.Nulls {
+	case bsgostuff_domain.NullsOrderEnumFirst:
+		return true
+	case bsgostuff_domain.NullsOrderEnumLast:
+		return false
+	}
+	return key.Desc
+}
+
+// cursorValue переводит значение поля сущности в текстовое представление для курсора
+func cursorValue(field any) (*string, error) {
+	valuer, ok := field.(driver.Valuer)
+	if !ok {
+		return nil, fmt.Errorf("%T does not implement driver.Valuer", field)
+	}
+	value, err := valuer.Value()
+	if err != nil {
+		return nil, err
+	}
+
+	var text string
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		text = v
+	case []byte:
+		text = `\x` + hex.EncodeToString(v)
+	case int64:
+		text = strconv.FormatInt(v, 10)
+	case float64:
+		text = strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		text = strconv.FormatBool(v)
+	case time.Time:
+		text = v.Format(time.RFC3339Nano)
+	default:
+		text = fmt.Sprint(v)
+	}
+	return &text, nil
+}
@@ -0,0 +1,399 @@
+package bsgostuff_infrastructure
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// DefaultMigrationsTable - таблица примененных миграций по умолчанию
+	DefaultMigrationsTable = "schema_migrations"
+	// DefaultMigrationsLockID - ключ advisory lock, которым сериализуются параллельные запуски
+	DefaultMigrationsLockID int64 = 7_300_112_450_151_014
+
+	// noTransactionDirective в первой строке файла отключает транзакцию
+	// (нужно, например, для CREATE INDEX CONCURRENTLY)
+	noTransactionDirective = "-- migrate:no-transaction"
+)
+
+var (
+	// ErrMigrationChanged - файл уже примененной миграции изменился
+	ErrMigrationChanged = errors.New("applied migration changed")
+	// ErrMigrationMissing - примененная миграция отсутствует среди файлов
+	ErrMigrationMissing = errors.New("applied migration missing")
+	// ErrMigrationIrreversible - у миграции нет down-файла
+	ErrMigrationIrreversible = errors.New("migration has no down script")
+)
+
+// migrationFilePattern - <версия>_<имя>.<up|down>.sql, например 0001_create_users.up.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration - версия схемы из пары up/down файлов
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+	// DownChecksum - контрольная сумма down-скрипта; проверяется перед откатом версии
+	DownChecksum string
+}
+
+// MigrationDirection - направление применения миграции
+type MigrationDirection string
+
+const (
+	MigrationDirectionUp   MigrationDirection = "up"
+	MigrationDirectionDown MigrationDirection = "down"
+)
+
+// MigrationStep - выполненный (или запланированный в DryRun) шаг
+type MigrationStep struct {
+	Version   int64
+	Name      string
+	Direction MigrationDirection
+	Duration  time.Duration
+}
+
+// MigratorOptions - параметры Migrator
+type MigratorOptions struct {
+	Table  string
+	LockID int64
+	// DryRun - только вычислить план, ничего не выполняя
+	DryRun bool
+}
+
+// Migrator применяет версионированные SQL-миграции из fs.FS.
+// Примененные версии и контрольные суммы хранятся в таблице Table,
+// параллельные запуски (несколько реплик сервиса) сериализуются через pg_advisory_lock.
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+	opts       MigratorOptions
+}
+
+// NewMigrator читает миграции из корня fsys (используйте fs.Sub для подкаталога)
+func NewMigrator(pool *pgxpool.Pool, fsys fs.FS, opts MigratorOptions) (*Migrator, error) {
+	if opts.Table == "" {
+		opts.Table = DefaultMigrationsTable
+	}
+	if opts.LockID == 0 {
+		opts.LockID = DefaultMigrationsLockID
+	}
+
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{pool: pool, migrations: migrations, opts: opts}, nil
+}
+
+// MustNewMigrator создает Migrator или паникует при ошибке
+func MustNewMigrator(pool *pgxpool.Pool, fsys fs.FS, opts MigratorOptions) *Migrator {
+	migrator, err := NewMigrator(pool, fsys, opts)
+	if err != nil {
+		panic(fmt.Errorf("failed to initialize migrator: %w", err))
+	}
+	return migrator
+}
+
+// LoadMigrations читает и проверяет миграции, упорядочивая их по версии
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: invalid version: %w", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d: conflicting names %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == string(MigrationDirectionUp) {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" {
+			return nil, fmt.Errorf("migration %d_%s: missing up script", migration.Version, migration.Name)
+		}
+		sum := sha256.Sum256([]byte(migration.Up))
+		migration.Checksum = hex.EncodeToString(sum[:])
+		downSum := sha256.Sum256([]byte(migration.Down))
+		migration.DownChecksum = hex.EncodeToString(downSum[:])
+		migrations = append(migrations, *migration)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return migrations, nil
+}
+
+// Migrations возвращает загруженные миграции
+func (m *Migrator) Migrations() []Migration {
+	return slices.Clone(m.migrations)
+}
+
+// Up применяет все непримененные миграции
+func (m *Migrator) Up(ctx context.Context) ([]MigrationStep, error) {
+	target := int64(0)
+	if len(m.migrations) > 0 {
+		target = m.migrations[len(m.migrations)-1].Version
+	}
+	return m.Migrate(ctx, target)
+}
+
+// Migrate приводит схему к версии target: применяет миграции до нее включительно
+// и откатывает примененные миграции с большей версией. target = 0 откатывает все.
+func (m *Migrator) Migrate(ctx context.Context, target int64) ([]MigrationStep, error) {
+	var steps []MigrationStep
+
+	err := m.withLock(ctx, func(conn *pgx.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		plan, err := m.plan(applied, target)
+		if err != nil {
+			return err
+		}
+
+		for _, step := range plan {
+			if m.opts.DryRun {
+				slog.InfoContext(ctx, "migration planned", slog.Int64("version", step.Version), slog.String("name", step.Name), slog.String("direction", string(step.Direction)))
+				steps = append(steps, step)
+				continue
+			}
+
+			started := time.Now()
+			if err := m.apply(ctx, conn, step); err != nil {
+				return fmt.Errorf("migration %d_%s %s: %w", step.Version, step.Name, step.Direction, err)
+			}
+			step.Duration = time.Since(started)
+
+			slog.InfoContext(ctx, "migration applied", slog.Int64("version", step.Version), slog.String("name", step.Name), slog.String("direction", string(step.Direction)), slog.Duration("duration", step.Duration))
+			steps = append(steps, step)
+		}
+
+		return nil
+	})
+
+	return steps, err
+}
+
+// Version возвращает максимальную примененную версию (0, если миграций не было)
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	var version int64
+	err := m.withConn(ctx, func(conn *pgx.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for v := range applied {
+			version = max(version, v)
+		}
+		return nil
+	})
+	return version, err
+}
+
+// appliedMigration - запись таблицы миграций
+type appliedMigration struct {
+	name     string
+	checksum string
+	// downChecksum пуст у версий, примененных до появления контрольной суммы down-скрипта
+	downChecksum string
+}
+
+// plan вычисляет шаги для перехода к target и проверяет контрольные суммы
+func (m *Migrator) plan(applied map[int64]appliedMigration, target int64) ([]MigrationStep, error) {
+	known := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+
+		record, ok := applied[migration.Version]
+		if ok && record.checksum != migration.Checksum {
+			return nil, fmt.Errorf("%w: %d_%s", ErrMigrationChanged, migration.Version, migration.Name)
+		}
+	}
+	for version, record := range applied {
+		if _, ok := known[version]; !ok {
+			return nil, fmt.Errorf("%w: %d_%s", ErrMigrationMissing, version, record.name)
+		}
+	}
+
+	var steps []MigrationStep
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok && migration.Version <= target {
+			steps = append(steps, MigrationStep{Version: migration.Version, Name: migration.Name, Direction: MigrationDirectionUp})
+		}
+	}
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		record, ok := applied[migration.Version]
+		if !ok || migration.Version <= target {
+			continue
+		}
+		if strings.TrimSpace(migration.Down) == "" {
+			return nil, fmt.Errorf("%w: %d_%s", ErrMigrationIrreversible, migration.Version, migration.Name)
+		}
+		if record.downChecksum != "" && record.downChecksum != migration.DownChecksum {
+			return nil, fmt.Errorf("%w: %d_%s (down)", ErrMigrationChanged, migration.Version, migration.Name)
+		}
+		steps = append(steps, MigrationStep{Version: migration.Version, Name: migration.Name, Direction: MigrationDirectionDown})
+	}
+
+	return steps, nil
+}
+
+// apply выполняет шаг и обновляет таблицу миграций
+func (m *Migrator) apply(ctx context.Context, conn *pgx.Conn, step MigrationStep) error {
+	idx := slices.IndexFunc(m.migrations, func(migration Migration) bool { return migration.Version == step.Version })
+	migration := m.migrations[idx]
+
+	script := migration.Up
+	record := fmt.Sprintf("INSERT INTO %s (version, name, checksum, down_checksum) VALUES ($1, $2, $3, $4)", m.opts.Table)
+	args := []any{migration.Version, migration.Name, migration.Checksum, migration.DownChecksum}
+	if step.Direction == MigrationDirectionDown {
+		script = migration.Down
+		record = fmt.Sprintf("DELETE FROM %s WHERE version = $1", m.opts.Table)
+		args = args[:1]
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(script), noTransactionDirective) {
+		if _, err := conn.Exec(ctx, script); err != nil {
+			return err
+		}
+		_, err := conn.Exec(ctx, record, args...)
+		return err
+	}
+
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, script); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, record, args...)
+		return err
+	})
+}
+
+// applied читает таблицу миграций, создавая ее при необходимости (кроме DryRun)
+func (m *Migrator) applied(ctx context.Context, conn *pgx.Conn) (map[int64]appliedMigration, error) {
+	if m.opts.DryRun {
+		var exists bool
+		if err := conn.QueryRow(ctx, "SELECT to_regclass($1) IS NOT NULL", m.opts.Table).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("check migrations table: %w", err)
+		}
+		if !exists {
+			return map[int64]appliedMigration{}, nil
+		}
+	} else {
+		query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	version       BIGINT PRIMARY KEY,
+	name          TEXT        NOT NULL,
+	checksum      TEXT        NOT NULL,
+	down_checksum TEXT,
+	applied_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+)`, m.opts.Table)
+		if _, err := conn.Exec(ctx, query); err != nil {
+			return nil, fmt.Errorf("create migrations table: %w", err)
+		}
+		// таблицы прежней версии создавались без down_checksum
+		query = fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS down_checksum TEXT", m.opts.Table)
+		if _, err := conn.Exec(ctx, query); err != nil {
+			return nil, fmt.Errorf("create migrations table: %w", err)
+		}
+	}
+
+	// down_checksum читается через to_jsonb: в DryRun таблица прежней версии может быть без этой колонки
+	rows, err := conn.Query(ctx, fmt.Sprintf("SELECT version, name, checksum, COALESCE(to_jsonb(m)->>'down_checksum', '') FROM %s AS m", m.opts.Table))
+	if err != nil {
+		return nil, fmt.Errorf("read migrations table: %w", err)
+	}
+
+	applied := make(map[int64]appliedMigration)
+	var (
+		version int64
+		record  appliedMigration
+	)
+	_, err = pgx.ForEachRow(rows, []any{&version, &record.name, &record.checksum, &record.downChecksum}, func() error {
+		applied[version] = record
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read migrations table: %w", err)
+	}
+
+	return applied, nil
+}
+
+// withConn выполняет fn на выделенном соединении пула
+func (m *Migrator) withConn(ctx context.Context, fn func(conn *pgx.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	return fn(conn.Conn())
+}
+
+// withLock выполняет fn под сессионной advisory-блокировкой.
+// Блокировка держится на одном соединении, поэтому все шаги выполняются на нем.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgx.Conn) error) error {
+	return m.withConn(ctx, func(conn *pgx.Conn) error {
+		if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", m.opts.LockID); err != nil {
+			return fmt.Errorf("acquire migrations lock: %w", err)
+		}
+		defer func() {
+			if _, err := conn.Exec(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", m.opts.LockID); err != nil {
+				slog.ErrorContext(ctx, "release migrations lock failed", slog.Any("error", err))
+			}
+		}()
+
+		return fn(conn)
+	})
+}
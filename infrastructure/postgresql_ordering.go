@@ -0,0 +1,366 @@
+package bsgostuff_infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	bsgostuff_domain "github.com/beavernsticks/go-stuff/domain"
+	bsgostuff_types "github.com/beavernsticks/go-stuff/types"
+	"github.com/jackc/pgx/v5"
+)
+
+// OrderingTable описывает упорядочиваемую таблицу для Ordering
+type OrderingTable struct {
+	// Name - имя таблицы (может включать схему)
+	Name string
+	// ScopeColumn - колонка области упорядочивания (по умолчанию parent_id)
+	ScopeColumn string
+	// Unscoped - вся таблица составляет одну область, scope игнорируется
+	Unscoped bool
+	// RankColumn - текстовая колонка ранга (по умолчанию rank)
+	RankColumn string
+	// IDColumn - колонка идентификатора (по умолчанию id)
+	IDColumn string
+	// Condition - дополнительное условие для строк области, например "is_deleted IS NOT TRUE"
+	Condition string
+}
+
+// Ordering хранит порядок элементов в лексикографических рангах (см. domain.RankBetween):
+// перемещение обновляет одну строку, а при слишком длинном ранге область перебалансируется.
+// Операции над одной областью сериализуются транзакционной advisory-блокировкой,
+// поэтому параллельные перемещения не получают одинаковых рангов.
+// Ранги сравниваются с COLLATE "C"; индекс должен быть (scope, rank COLLATE "C").
+type Ordering struct {
+	db    Querier
+	tx    *TxManager
+	table OrderingTable
+}
+
+func NewOrdering(db Querier, tx *TxManager, table OrderingTable) (*Ordering, error) {
+	if table.Name == "" {
+		return nil, fmt.Errorf("%w: table name is required", bsgostuff_domain.ErrInvalidArgument)
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("%w: transaction manager is required", bsgostuff_domain.ErrInvalidArgument)
+	}
+
+	switch {
+	case table.Unscoped:
+		table.ScopeColumn = ""
+	case table.ScopeColumn == "":
+		table.ScopeColumn = "parent_id"
+	}
+	if table.RankColumn == "" {
+		table.RankColumn = "rank"
+	}
+	if table.IDColumn == "" {
+		table.IDColumn = "id"
+	}
+
+	return &Ordering{db: db, tx: tx, table: table}, nil
+}
+
+func MustNewOrdering(db Querier, tx *TxManager, table OrderingTable) *Ordering {
+	ordering, err := NewOrdering(db, tx, table)
+	if err != nil {
+		panic(fmt.Errorf("failed to initialize ordering: %w", err))
+	}
+	return ordering
+}
+
+// Append возвращает ранг для нового элемента в конце области.
+// Вызывается в той же транзакции TxManager, что и вставка: блокировка области
+// держится до коммита, и параллельные вставки получают разные ранги.
+func (o *Ordering) Append(ctx context.Context, scope bsgostuff_types.ID) (rank string, err error) {
+	err = o.tx.Do(ctx, func(ctx context.Context) error {
+		q := QuerierFromContext(ctx, o.db)
+		if err := o.lock(ctx, q, scope); err != nil {
+			return err
+		}
+
+		last, err := o.edge(ctx, q, scope, bsgostuff_types.ID{}, "max")
+		if err != nil {
+			return err
+		}
+		if rank, err = bsgostuff_domain.RankBetween(last, ""); err != nil {
+			return err
+		}
+		if len(rank) <= bsgostuff_domain.MaxRankLength {
+			return nil
+		}
+
+		count, err := o.rebalance(ctx, q, scope)
+		if err != nil {
+			return err
+		}
+		rank = bsgostuff_domain.RankSequence(count + 1)[count]
+		return nil
+	})
+	return rank, err
+}
+
+// Move перемещает элемент id области scope и возвращает его новый ранг.
+// Если элемент уже на месте, ранг не меняется.
+func (o *Ordering) Move(ctx context.Context, scope, id bsgostuff_types.ID, move bsgostuff_domain.Move) (rank string, err error) {
+	if err := move.Validate(); err != nil {
+		return "", err
+	}
+	if move.After.IsSet() && move.After.GetValue() == id.GetValue() || move.Before.IsSet() && move.Before.GetValue() == id.GetValue() {
+		return "", bsgostuff_domain.NewInvalidArgumentError(bsgostuff_domain.FieldViolation{
+			Field: "move", Code: bsgostuff_domain.ViolationInvalid, Message: "item cannot be moved relative to itself",
+		})
+	}
+
+	err = o.tx.Do(ctx, func(ctx context.Context) error {
+		q := QuerierFromContext(ctx, o.db)
+		if err := o.lock(ctx, q, scope); err != nil {
+			return err
+		}
+
+		current, err := o.rank(ctx, q, scope, id)
+		if err != nil {
+			return err
+		}
+
+		prev, next, moved, err := o.neighbours(ctx, q, scope, id, current, move)
+		if err != nil || !moved {
+			rank = current
+			return err
+		}
+
+		// Совпадающие ранги (например, записанные в обход Ordering) исправляет перебалансировка
+		if next != "" && prev >= next {
+			if _, err := o.rebalance(ctx, q, scope); err != nil {
+				return err
+			}
+			if current, err = o.rank(ctx, q, scope, id); err != nil {
+				return err
+			}
+			if prev, next, moved, err = o.neighbours(ctx, q, scope, id, current, move); err != nil || !moved {
+				rank = current
+				return err
+			}
+		}
+
+		if rank, err = bsgostuff_domain.RankBetween(prev, next); err != nil {
+			return err
+		}
+		if err := o.setRank(ctx, q, id, rank); err != nil {
+			return err
+		}
+		if len(rank) <= bsgostuff_domain.MaxRankLength {
+			return nil
+		}
+
+		if _, err := o.rebalance(ctx, q, scope); err != nil {
+			return err
+		}
+		rank, err = o.rank(ctx, q, scope, id)
+		return err
+	})
+	return rank, err
+}
+
+// Rebalance равномерно перераспределяет ранги области, сохраняя порядок
+func (o *Ordering) Rebalance(ctx context.Context, scope bsgostuff_types.ID) error {
+	return o.tx.Do(ctx, func(ctx context.Context) error {
+		q := QuerierFromContext(ctx, o.db)
+		if err := o.lock(ctx, q, scope); err != nil {
+			return err
+		}
+		_, err := o.rebalance(ctx, q, scope)
+		return err
+	})
+}
+
+// neighbours возвращает ранги, между которыми должен оказаться элемент;
+// moved = false, если элемент уже на месте
+func (o *Ordering) neighbours(ctx context.Context, q Querier, scope, id bsgostuff_types.ID, current string, move bsgostuff_domain.Move) (prev, next string, moved bool, err error) {
+	switch {
+	case move.After.IsSet():
+		if prev, err = o.rank(ctx, q, scope, move.After); err != nil {
+			return "", "", false, err
+		}
+		ranks, err := o.adjacent(ctx, q, scope, prev, ">", 1)
+		if err != nil || len(ranks) > 0 && ranks[0] == current {
+			return "", "", false, err
+		}
+		if len(ranks) > 0 {
+			next = ranks[0]
+		}
+		return prev, next, true, nil
+
+	case move.Before.IsSet():
+		if next, err = o.rank(ctx, q, scope, move.Before); err != nil {
+			return "", "", false, err
+		}
+		ranks, err := o.adjacent(ctx, q, scope, next, "<", 1)
+		if err != nil || len(ranks) > 0 && ranks[0] == current {
+			return "", "", false, err
+		}
+		if len(ranks) > 0 {
+			prev = ranks[0]
+		}
+		return prev, next, true, nil
+	}
+
+	switch move.Direction.GetValue() {
+	case bsgostuff_domain.MoveDirectionEnumUp, bsgostuff_domain.MoveDirectionEnumDown:
+		up := move.Direction.GetValue() == bsgostuff_domain.MoveDirectionEnumUp
+		operator := ">"
+		if up {
+			operator = "<"
+		}
+
+		// Два соседа в направлении движения: элемент встает между ними
+		ranks, err := o.adjacent(ctx, q, scope, current, operator, 2)
+		if err != nil || len(ranks) == 0 {
+			return "", "", false, err
+		}
+		ranks = append(ranks, "")
+		if up {
+			return ranks[1], ranks[0], true, nil
+		}
+		return ranks[0], ranks[1], true, nil
+
+	case bsgostuff_domain.MoveDirectionEnumTop:
+		first, err := o.edge(ctx, q, scope, id, "min")
+		if err != nil || first == "" || current < first {
+			return "", "", false, err
+		}
+		return "", first, true, nil
+
+	case bsgostuff_domain.MoveDirectionEnumBottom:
+		last, err := o.edge(ctx, q, scope, id, "max")
+		if err != nil || last == "" || current > last {
+			return "", "", false, err
+		}
+		return last, "", true, nil
+	}
+
+	return "", "", false, nil
+}
+
+// lock берет транзакционную блокировку области
+func (o *Ordering) lock(ctx context.Context, q Querier, scope bsgostuff_types.ID) error {
+	key := o.table.Name
+	if o.table.ScopeColumn != "" {
+		key += ":" + scope.String()
+	}
+	if _, err := q.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtextextended($1, 0))", key); err != nil {
+		return fmt.Errorf("ordering lock failed: %w", err)
+	}
+	return nil
+}
+
+// rank возвращает ранг элемента области
+func (o *Ordering) rank(ctx context.Context, q Querier, scope, id bsgostuff_types.ID) (string, error) {
+	where, args := o.scoped(fmt.Sprintf("%s = $1", o.table.IDColumn), id)
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s", o.table.RankColumn, o.table.Name, where)
+
+	var rank string
+	if err := q.QueryRow(ctx, query, o.args(scope, args...)...).Scan(&rank); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", bsgostuff_domain.NewNotFoundError(o.table.Name, id.String()).WithCause(err)
+		}
+		return "", fmt.Errorf("ordering select failed: %w", err)
+	}
+	return rank, nil
+}
+
+// adjacent возвращает до limit рангов области, ближайших к rank со стороны operator ("<" или ">")
+func (o *Ordering) adjacent(ctx context.Context, q Querier, scope bsgostuff_types.ID, rank, operator string, limit int) ([]string, error) {
+	direction := "ASC"
+	if operator == "<" {
+		direction = "DESC"
+	}
+
+	where, args := o.scoped(fmt.Sprintf(`%s COLLATE "C" %s $1`, o.table.RankColumn, operator), rank)
+	query := fmt.Sprintf(`SELECT %[1]s FROM %[2]s WHERE %[3]s ORDER BY %[1]s COLLATE "C" %[4]s LIMIT %[5]d`,
+		o.table.RankColumn, o.table.Name, where, direction, limit)
+
+	rows, err := q.Query(ctx, query, o.args(scope, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("ordering select failed: %w", err)
+	}
+	ranks, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("ordering select failed: %w", err)
+	}
+	return ranks, nil
+}
+
+// edge возвращает минимальный или максимальный ранг области без элемента exclude ("" - область пуста)
+func (o *Ordering) edge(ctx context.Context, q Querier, scope, exclude bsgostuff_types.ID, aggregate string) (string, error) {
+	where, args := o.scoped("TRUE")
+	if exclude.IsSet() {
+		where, args = o.scoped(fmt.Sprintf("%s <> $1", o.table.IDColumn), exclude)
+	}
+	query := fmt.Sprintf(`SELECT %s(%s COLLATE "C") FROM %s WHERE %s`, aggregate, o.table.RankColumn, o.table.Name, where)
+
+	var rank *string
+	if err := q.QueryRow(ctx, query, o.args(scope, args...)...).Scan(&rank); err != nil {
+		return "", fmt.Errorf("ordering select failed: %w", err)
+	}
+	return bsgostuff_types.DerefZero(rank), nil
+}
+
+func (o *Ordering) setRank(ctx context.Context, q Querier, id bsgostuff_types.ID, rank string) error {
+	query := fmt.Sprintf("UPDATE %s SET %s = $1 WHERE %s = $2", o.table.Name, o.table.RankColumn, o.table.IDColumn)
+	if _, err := q.Exec(ctx, query, rank, id); err != nil {
+		return fmt.Errorf("ordering update failed: %w", err)
+	}
+	return nil
+}
+
+// rebalance присваивает элементам области ранги RankSequence в текущем порядке
+// и возвращает число элементов
+func (o *Ordering) rebalance(ctx context.Context, q Querier, scope bsgostuff_types.ID) (int, error) {
+	where, args := o.scoped("TRUE")
+
+	var count int
+	query := fmt.Sprintf("SELECT count(*) FROM %s WHERE %s", o.table.Name, where)
+	if err := q.QueryRow(ctx, query, o.args(scope, args...)...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("ordering count failed: %w", err)
+	}
+	if count == 0 {
+		return 0, nil
+	}
+
+	// Ранги сопоставляются строкам по номеру в текущем порядке
+	where, args = o.scoped("TRUE", bsgostuff_domain.RankSequence(count))
+	query = fmt.Sprintf(`UPDATE %[1]s AS t SET %[2]s = r.rank
+FROM (
+	SELECT %[3]s, row_number() OVER (ORDER BY %[2]s COLLATE "C", %[3]s) AS n FROM %[1]s WHERE %[4]s
+) AS o
+JOIN unnest($1::text[]) WITH ORDINALITY AS r(rank, n) USING (n)
+WHERE t.%[3]s = o.%[3]s`, o.table.Name, o.table.RankColumn, o.table.IDColumn, where)
+	if _, err := q.Exec(ctx, query, o.args(scope, args...)...); err != nil {
+		return 0, fmt.Errorf("ordering rebalance failed: %w", err)
+	}
+
+	return count, nil
+}
+
+// scoped дополняет условие cond (с параметрами args) условиями области и Condition.
+// Параметр области, если он нужен, следует за args (см. args).
+func (o *Ordering) scoped(cond string, args ...any) (string, []any) {
+	if o.table.ScopeColumn != "" {
+		cond += fmt.Sprintf(" AND %s IS NOT DISTINCT FROM $%d", o.table.ScopeColumn, len(args)+1)
+	}
+	if o.table.Condition != "" {
+		cond += " AND (" + o.table.Condition + ")"
+	}
+	return cond, args
+}
+
+// args добавляет к args значение области, если таблица разбита на области
+func (o *Ordering) args(scope bsgostuff_types.ID, args ...any) []any {
+	if o.table.ScopeColumn != "" {
+		args = append(args, scope)
+	}
+	return args
+}
+
+var _ bsgostuff_domain.Orderer = (*Ordering)(nil)
@@ -0,0 +1,565 @@
+package bsgostuff_infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	bsgostuff_domain "github.com/beavernsticks/go-stuff/domain"
+	bsgostuff_types "github.com/beavernsticks/go-stuff/types"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolationCode - код ошибки PostgreSQL при нарушении уникальности
+const uniqueViolationCode = "23505"
+
+// Querier - общий контракт pgxpool.Pool, pgx.Conn и pgx.Tx
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Table описывает таблицу сущности для Repository
+type Table struct {
+	// Name - имя таблицы (может включать схему)
+	Name string
+	// SortFields - допустимые поля сортировки: имя поля из domain.Sort -> SQL-выражение.
+	// Если не задано, допускается сортировка по любой колонке сущности.
+	// ListByCursor поддерживает только выражения, совпадающие с колонками сущности.
+	SortFields bsgostuff_domain.SortFields
+	// DefaultSort - колонка сортировки по умолчанию (по умолчанию created_at или id)
+	DefaultSort string
+	// FilterFields - допустимые поля фильтрации. Если не задано, допускается
+	// фильтрация по любой колонке сущности с типом значения ее поля.
+	FilterFields bsgostuff_domain.FilterFields
+	// PageSize - размер страницы List, ListPage и ListByCursor (по умолчанию 100, максимум 1000)
+	PageSize bsgostuff_domain.PageSize
+	// Cursors - кодек курсоров ListByCursor (по умолчанию без подписи)
+	Cursors *bsgostuff_domain.CursorCodec
+}
+
+type entityKind int
+
+const (
+	entityKindPlain entityKind = iota
+	entityKindEntity
+	entityKindDeletable
+	entityKindUnmodified
+)
+
+// repositoryColumn - колонка таблицы и путь к полю структуры
+type repositoryColumn struct {
+	name  string
+	index []int
+}
+
+// Repository реализует CRUD для структур с полями bsgostuff_types,
+// встраивающих domain.Entity, domain.DeletableEntity или domain.UnmodifiedEntity.
+// Колонки берутся из тегов db (или имен полей в snake_case), как в BuildUpdateSet.
+// Запросы выполняются в транзакции из контекста, если она открыта через TxManager.
+type Repository[T any] struct {
+	db      Querier
+	table   Table
+	kind    entityKind
+	columns []repositoryColumn
+	idIndex []int
+}
+
+// NewRepository создает репозиторий для таблицы
+func NewRepository[T any](db Querier, table Table) (*Repository[T], error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: repository entity must be a struct, got %s", bsgostuff_domain.ErrInvalidArgument, t)
+	}
+	if table.Name == "" {
+		return nil, fmt.Errorf("%w: table name is required", bsgostuff_domain.ErrInvalidArgument)
+	}
+
+	r := &Repository[T]{db: db, table: table}
+	r.collectColumns(t, nil)
+
+	if len(r.columns) == 0 {
+		return nil, fmt.Errorf("%w: %s has no columns", bsgostuff_domain.ErrInvalidArgument, t)
+	}
+	if r.idIndex == nil {
+		return nil, fmt.Errorf("%w: %s has no id column", bsgostuff_domain.ErrInvalidArgument, t)
+	}
+	if r.table.DefaultSort == "" {
+		r.table.DefaultSort = "id"
+		if r.hasColumn("created_at") {
+			r.table.DefaultSort = "created_at"
+		}
+	}
+	if r.table.SortFields == nil {
+		r.table.SortFields = make(bsgostuff_domain.SortFields, len(r.columns))
+		for _, column := range r.columns {
+			r.table.SortFields[column.name] = column.name
+		}
+	}
+	if r.table.FilterFields == nil {
+		r.table.FilterFields = make(bsgostuff_domain.FilterFields, len(r.columns))
+		for _, column := range r.columns {
+			fieldType := t.FieldByIndex(column.index).Type
+			r.table.FilterFields[column.name] = bsgostuff_domain.FilterField{
+				Expression: column.name,
+				NewValue: func() bsgostuff_domain.FilterValue {
+					return reflect.New(fieldType).Interface().(bsgostuff_domain.FilterValue)
+				},
+			}
+		}
+	}
+	if r.table.PageSize.Default <= 0 {
+		r.table.PageSize.Default = 100
+	}
+	if r.table.PageSize.Max <= 0 {
+		r.table.PageSize.Max = max(1000, r.table.PageSize.Default)
+	}
+	if r.table.Cursors == nil {
+		r.table.Cursors = bsgostuff_domain.NewCursorCodec(nil)
+	}
+
+	return r, nil
+}
+
+// MustNewRepository создает репозиторий или паникует при ошибке
+func MustNewRepository[T any](db Querier, table Table) *Repository[T] {
+	r, err := NewRepository[T](db, table)
+	if err != nil {
+		panic(fmt.Errorf("failed to initialize repository: %w", err))
+	}
+	return r
+}
+
+// collectColumns рекурсивно собирает колонки по правилам BuildUpdateSet
+func (r *Repository[T]) collectColumns(t reflect.Type, index []int) {
+	settableType := reflect.TypeFor[settable]()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		column, ok := columnName(sf)
+		if !ok {
+			continue
+		}
+
+		fieldIndex := append(append([]int{}, index...), i)
+
+		if sf.Type.Implements(settableType) {
+			r.columns = append(r.columns, repositoryColumn{name: column, index: fieldIndex})
+			if column == "id" && r.idIndex == nil {
+				r.idIndex = fieldIndex
+			}
+			continue
+		}
+		if sf.Type.Kind() != reflect.Struct {
+			continue
+		}
+
+		switch sf.Type {
+		case entityReflectType:
+			r.kind = entityKindEntity
+		case deletableEntityReflectType:
+			r.kind = entityKindDeletable
+		case unmodifiedEntityReflectType:
+			r.kind = entityKindUnmodified
+		default:
+			if !sf.Anonymous {
+				continue
+			}
+		}
+		r.collectColumns(sf.Type, fieldIndex)
+	}
+}
+
+func (r *Repository[T]) hasColumn(name string) bool {
+	for _, column := range r.columns {
+		if column.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// selectColumns возвращает список колонок для SELECT/RETURNING
+func (r *Repository[T]) selectColumns() string {
+	names := make([]string, len(r.columns))
+	for i, column := range r.columns {
+		names[i] = column.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// where возвращает условие WHERE из фильтра и признака мягкого удаления
+func (r *Repository[T]) where(filter bsgostuff_domain.Filter) (string, []any, error) {
+	condition, args, err := FilterSQL(filter, r.table.FilterFields, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	return condition + r.notDeleted(), args, nil
+}
+
+// notDeleted возвращает условие, исключающее мягко удаленные записи
+func (r *Repository[T]) notDeleted() string {
+	if r.kind == entityKindDeletable {
+		return " AND is_deleted IS NOT TRUE"
+	}
+	return ""
+}
+
+// scanTargets возвращает указатели на поля сущности в порядке колонок
+func (r *Repository[T]) scanTargets(entity *T) []any {
+	v := reflect.ValueOf(entity).Elem()
+	targets := make([]any, len(r.columns))
+	for i, column := range r.columns {
+		targets[i] = v.FieldByIndex(column.index).Addr().Interface()
+	}
+	return targets
+}
+
+// querier возвращает транзакцию из контекста (см. TxManager) или пул
+func (r *Repository[T]) querier(ctx context.Context) Querier {
+	return QuerierFromContext(ctx, r.db)
+}
+
+// entityID возвращает значение колонки id
+func (r *Repository[T]) entityID(entity *T) any {
+	return reflect.ValueOf(entity).Elem().FieldByIndex(r.idIndex).Interface()
+}
+
+// Get возвращает сущность по идентификатору
+func (r *Repository[T]) Get(ctx context.Context, id bsgostuff_types.ID) (T, error) {
+	var entity T
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1%s", r.selectColumns(), r.table.Name, r.notDeleted())
+	if err := r.querier(ctx).QueryRow(ctx, query, id).Scan(r.scanTargets(&entity)...); err != nil {
+		return entity, r.mapError(err, id.String())
+	}
+
+	return entity, nil
+}
+
+// List возвращает страницу сущностей с учетом пагинации и сортировки.
+// Limit не задан - Table.PageSize.Default, больше Table.PageSize.Max - ошибка ErrInvalidArgument.
+func (r *Repository[T]) List(ctx context.Context, filter bsgostuff_domain.Filter, pagination bsgostuff_domain.Pagination, sorts ...bsgostuff_domain.Sort) ([]T, error) {
+	pagination, err := r.resolvePagination(pagination)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err := r.listQuery(r.selectColumns(), filter, pagination, sorts)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+// ListPage возвращает страницу сущностей вместе с общим числом.
+// Число считается оконной функцией в том же запросе; отдельный COUNT
+// выполняется, только если страница оказалась за пределами выборки.
+// Размер страницы ограничивается как в List.
+func (r *Repository[T]) ListPage(ctx context.Context, filter bsgostuff_domain.Filter, pagination bsgostuff_domain.Pagination, sorts ...bsgostuff_domain.Sort) (bsgostuff_domain.Page[T], error) {
+	pagination, err := r.resolvePagination(pagination)
+	if err != nil {
+		return bsgostuff_domain.Page[T]{}, err
+	}
+	query, args, err := r.listQuery(r.selectColumns()+", count(*) OVER ()", filter, pagination, sorts)
+	if err != nil {
+		return bsgostuff_domain.Page[T]{}, err
+	}
+
+	rows, err := r.querier(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return bsgostuff_domain.Page[T]{}, r.mapError(err, "")
+	}
+	defer rows.Close()
+
+	var total int64
+	entities := make([]T, 0)
+	for rows.Next() {
+		var entity T
+		if err := rows.Scan(append(r.scanTargets(&entity), &total)...); err != nil {
+			return bsgostuff_domain.Page[T]{}, r.mapError(err, "")
+		}
+		entities = append(entities, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return bsgostuff_domain.Page[T]{}, r.mapError(err, "")
+	}
+
+	if len(entities) == 0 && pagination.Skip.GetValue() > 0 {
+		if total, err = r.Count(ctx, filter); err != nil {
+			return bsgostuff_domain.Page[T]{}, err
+		}
+	}
+
+	return bsgostuff_domain.NewPage(entities, total, pagination), nil
+}
+
+// Count возвращает число сущностей, подходящих под фильтр (без мягко удаленных)
+func (r *Repository[T]) Count(ctx context.Context, filter bsgostuff_domain.Filter) (int64, error) {
+	var total int64
+
+	where, args, err := r.where(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf("SELECT count(*) FROM %s WHERE %s", r.table.Name, where)
+	if err := r.querier(ctx).QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, r.mapError(err, "")
+	}
+
+	return total, nil
+}
+
+// resolvePagination подставляет размер страницы по умолчанию и проверяет максимальный
+func (r *Repository[T]) resolvePagination(pagination bsgostuff_domain.Pagination) (bsgostuff_domain.Pagination, error) {
+	limit, err := r.table.PageSize.Resolve("pagination.limit", pagination.Limit)
+	if err != nil {
+		return pagination, err
+	}
+	pagination.Limit = bsgostuff_types.NewInt(limit)
+	return pagination, nil
+}
+
+// listQuery строит SELECT с фильтром, сортировкой, LIMIT и OFFSET
+func (r *Repository[T]) listQuery(columns string, filter bsgostuff_domain.Filter, pagination bsgostuff_domain.Pagination, sorts []bsgostuff_domain.Sort) (string, []any, error) {
+	keys, err := r.sortKeys(sorts)
+	if err != nil {
+		return "", nil, err
+	}
+	where, args, err := r.where(filter)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var query strings.Builder
+	fmt.Fprintf(&query, "SELECT %s FROM %s WHERE %s ORDER BY %s", columns, r.table.Name, where, orderByClause(keys))
+
+	if pagination.Limit.IsSet() && !pagination.Limit.IsNull() {
+		args = append(args, pagination.Limit.GetValue())
+		fmt.Fprintf(&query, " LIMIT $%d", len(args))
+	}
+	if pagination.Skip.IsSet() && !pagination.Skip.IsNull() {
+		args = append(args, pagination.Skip.GetValue())
+		fmt.Fprintf(&query, " OFFSET $%d", len(args))
+	}
+
+	return query.String(), args, nil
+}
+
+// ListByCursor возвращает страницу сущностей после позиции pagination.Cursor.
+// Курсор действителен только для той же сортировки, с которой он получен.
+func (r *Repository[T]) ListByCursor(ctx context.Context, filter bsgostuff_domain.Filter, pagination bsgostuff_domain.CursorPagination, sorts ...bsgostuff_domain.Sort) ([]T, bsgostuff_domain.CursorPageInfo, error) {
+	var pageInfo bsgostuff_domain.CursorPageInfo
+
+	limit, err := r.table.PageSize.Resolve("pagination.limit", pagination.Limit)
+	if err != nil {
+		return nil, pageInfo, err
+	}
+	keys, err := r.sortKeys(sorts)
+	if err != nil {
+		return nil, pageInfo, err
+	}
+
+	where, args, err := r.where(filter)
+	if err != nil {
+		return nil, pageInfo, err
+	}
+
+	var query strings.Builder
+	fmt.Fprintf(&query, "SELECT %s FROM %s WHERE %s", r.selectColumns(), r.table.Name, where)
+
+	if pagination.Cursor.IsSet() && !pagination.Cursor.IsNull() && pagination.Cursor.GetValue() != "" {
+		cursor, err := r.table.Cursors.Decode(pagination.Cursor.GetValue())
+		if err != nil {
+			return nil, pageInfo, err
+		}
+		if !cursor.Matches(keys) {
+			return nil, pageInfo, bsgostuff_domain.NewInvalidArgumentError(bsgostuff_domain.FieldViolation{
+				Field: "pagination.cursor", Code: bsgostuff_domain.ViolationInvalid, Message: "cursor does not match sort order",
+			})
+		}
+
+		predicate, predicateArgs, err := KeysetPredicate(cursor.Keys, len(args))
+		if err != nil {
+			return nil, pageInfo, err
+		}
+		fmt.Fprintf(&query, " AND %s", predicate)
+		args = append(args, predicateArgs...)
+	}
+
+	// Лишняя строка показывает, есть ли следующая страница
+	args = append(args, limit+1)
+	fmt.Fprintf(&query, " ORDER BY %s LIMIT $%d", orderByClause(keys), len(args))
+
+	entities, err := r.query(ctx, query.String(), args...)
+	if err != nil {
+		return nil, pageInfo, err
+	}
+
+	pageInfo.HasMore = bsgostuff_types.NewBool(int64(len(entities)) > limit)
+	if !pageInfo.HasMore.GetValue() {
+		return entities, pageInfo, nil
+	}
+	entities = entities[:limit]
+
+	cursor, err := r.cursor(&entities[len(entities)-1], keys)
+	if err != nil {
+		return nil, pageInfo, err
+	}
+	pageInfo.NextCursor = bsgostuff_types.NewString(cursor)
+
+	return entities, pageInfo, nil
+}
+
+// cursor кодирует позицию сущности для сортировки keys
+func (r *Repository[T]) cursor(entity *T, keys []bsgostuff_domain.CursorKey) (string, error) {
+	v := reflect.ValueOf(entity).Elem()
+
+	cursor := bsgostuff_domain.Cursor{Keys: slices.Clone(keys)}
+	for i, key := range cursor.Keys {
+		idx := slices.IndexFunc(r.columns, func(column repositoryColumn) bool { return column.name == key.Column })
+		if idx < 0 {
+			return "", fmt.Errorf("%w: sort column %s is not selected", bsgostuff_domain.ErrInternal, key.Column)
+		}
+
+		value, err := cursorValue(v.FieldByIndex(r.columns[idx].index).Interface())
+		if err != nil {
+			return "", fmt.Errorf("%w: cursor value %s: %w", bsgostuff_domain.ErrInternal, key.Column, err)
+		}
+		cursor.Keys[i].Value = value
+	}
+
+	return r.table.Cursors.Encode(cursor)
+}
+
+// query выполняет SELECT и сканирует строки в сущности
+func (r *Repository[T]) query(ctx context.Context, query string, args ...any) ([]T, error) {
+	rows, err := r.querier(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.mapError(err, "")
+	}
+	defer rows.Close()
+
+	entities := make([]T, 0)
+	for rows.Next() {
+		var entity T
+		if err := rows.Scan(r.scanTargets(&entity)...); err != nil {
+			return nil, r.mapError(err, "")
+		}
+		entities = append(entities, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapError(err, "")
+	}
+
+	return entities, nil
+}
+
+// sortKeys возвращает ключи сортировки по белому списку полей; id добавляется для стабильного порядка
+func (r *Repository[T]) sortKeys(sorts []bsgostuff_domain.Sort) ([]bsgostuff_domain.CursorKey, error) {
+	return SortKeys(sorts, r.table.SortFields, r.table.DefaultSort, "id")
+}
+
+// Create вставляет установленные поля сущности и заполняет ее значениями из базы
+// (включая значения по умолчанию)
+func (r *Repository[T]) Create(ctx context.Context, entity *T) error {
+	v := reflect.ValueOf(entity).Elem()
+
+	columns := make([]string, 0, len(r.columns))
+	placeholders := make([]string, 0, len(r.columns))
+	args := make([]any, 0, len(r.columns))
+	for _, column := range r.columns {
+		fv := v.FieldByIndex(column.index)
+		if fv.Kind() == reflect.Pointer && fv.IsNil() {
+			continue
+		}
+		field := fv.Interface()
+		if !field.(settable).IsSet() {
+			continue
+		}
+		args = append(args, field)
+		columns = append(columns, column.name)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+	if len(columns) == 0 {
+		return fmt.Errorf("%w: no fields set for insert", bsgostuff_domain.ErrInvalidArgument)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) RETURNING %s",
+		r.table.Name, strings.Join(columns, ", "), strings.Join(placeholders, ", "), r.selectColumns())
+	if err := r.querier(ctx).QueryRow(ctx, query, args...).Scan(r.scanTargets(entity)...); err != nil {
+		return r.mapError(err, "")
+	}
+
+	return nil
+}
+
+// Update сохраняет установленные поля сущности (частичное обновление через BuildUpdateSet)
+// и заполняет ее актуальными значениями из базы.
+// Сущности domain.UnmodifiedEntity обновлять запрещено.
+func (r *Repository[T]) Update(ctx context.Context, entity *T) error {
+	if r.kind == entityKindUnmodified {
+		return fmt.Errorf("%w: %s entities cannot be updated", bsgostuff_domain.ErrForbidden, r.table.Name)
+	}
+
+	id := r.entityID(entity)
+	query, args, err := BuildUpdateQuery(r.table.Name, entity, "id = $1"+r.notDeleted(), id)
+	if err != nil {
+		return err
+	}
+	query += " RETURNING " + r.selectColumns()
+
+	if err := r.querier(ctx).QueryRow(ctx, query, args...).Scan(r.scanTargets(entity)...); err != nil {
+		return r.mapError(err, fmt.Sprint(id))
+	}
+
+	return nil
+}
+
+// Delete удаляет сущность по идентификатору.
+// domain.DeletableEntity удаляется мягко: is_deleted = true.
+func (r *Repository[T]) Delete(ctx context.Context, id bsgostuff_types.ID) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1", r.table.Name)
+	args := []any{id}
+	if r.kind == entityKindDeletable {
+		query = fmt.Sprintf("UPDATE %s SET is_deleted = TRUE, updated_at = $2 WHERE id = $1%s", r.table.Name, r.notDeleted())
+		args = append(args, bsgostuff_types.NewCurrentTimestamp())
+	}
+
+	tag, err := r.querier(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return r.mapError(err, id.String())
+	}
+	if tag.RowsAffected() == 0 {
+		return bsgostuff_domain.NewNotFoundError(r.table.Name, id.String())
+	}
+
+	return nil
+}
+
+// mapError преобразует ошибки PostgreSQL в доменные:
+// pgx.ErrNoRows -> ErrNotFound, нарушение уникальности -> ErrDuplicate
+func (r *Repository[T]) mapError(err error, resourceName string) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return bsgostuff_domain.NewNotFoundError(r.table.Name, resourceName).WithCause(err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return bsgostuff_domain.NewDuplicateError(r.table.Name, resourceName).
+			WithMetadata("constraint", pgErr.ConstraintName).
+			WithCause(err)
+	}
+
+	return fmt.Errorf("%s: %w", r.table.Name, err)
+}
@@ -0,0 +1,103 @@
+package bsgostuff_infrastructure
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	bsgostuff_domain "github.com/beavernsticks/go-stuff/domain"
+)
+
+// OrderBy строит ORDER BY (без ключевого слова) по белому списку fields.
+// Пустой sorts или ключ без Field дают сортировку по defaultExpression; tiebreaker (обычно id)
+// добавляется в конец для стабильного порядка, если его еще нет среди ключей.
+// Неизвестные поля и некорректный Nulls - ошибка ErrInvalidArgument.
+func OrderBy(sorts []bsgostuff_domain.Sort, fields bsgostuff_domain.SortFields, defaultExpression, tiebreaker string) (string, error) {
+	keys, err := SortKeys(sorts, fields, defaultExpression, tiebreaker)
+	if err != nil {
+		return "", err
+	}
+	return orderByClause(keys), nil
+}
+
+// SortKeys разрешает ключи сортировки в SQL-выражения из белого списка (см. OrderBy).
+// Ключи используются также для курсоров KeysetPredicate.
+func SortKeys(sorts []bsgostuff_domain.Sort, fields bsgostuff_domain.SortFields, defaultExpression, tiebreaker string) ([]bsgostuff_domain.CursorKey, error) {
+	keys := make([]bsgostuff_domain.CursorKey, 0, len(sorts)+1)
+	violations := make([]bsgostuff_domain.FieldViolation, 0)
+	seen := make(map[string]bool, len(sorts))
+
+	for i, sort := range sorts {
+		prefix := fmt.Sprintf("sort[%d]", i)
+
+		// Ключ без поля задает направление сортировки по умолчанию
+		field := sort.Field.GetValue()
+		expression, ok := fields[field]
+		if field == "" {
+			expression, ok = defaultExpression, defaultExpression != ""
+		}
+
+		switch {
+		case !ok && field == "":
+			violations = append(violations, bsgostuff_domain.FieldViolation{
+				Field: prefix + ".field", Code: bsgostuff_domain.ViolationRequired, Message: "is required",
+			})
+			continue
+		case !ok:
+			violations = append(violations, bsgostuff_domain.FieldViolation{
+				Field: prefix + ".field", Code: bsgostuff_domain.ViolationOneOf, Message: fmt.Sprintf("unsupported sort field %q", field),
+			})
+			continue
+		case seen[field]:
+			violations = append(violations, bsgostuff_domain.FieldViolation{
+				Field: prefix + ".field", Code: bsgostuff_domain.ViolationInvalid, Message: fmt.Sprintf("duplicate sort field %q", field),
+			})
+			continue
+		}
+		seen[field] = true
+
+		nulls := sort.Nulls.GetValue()
+		if nulls != bsgostuff_domain.NullsOrderEnumUnknown && !nulls.Valid() {
+			violations = append(violations, bsgostuff_domain.FieldViolation{
+				Field: prefix + ".nulls", Code: bsgostuff_domain.ViolationEnum, Message: fmt.Sprintf("unsupported nulls order %q", nulls),
+			})
+			continue
+		}
+
+		keys = append(keys, bsgostuff_domain.CursorKey{Column: expression, Desc: sort.IsReversed.GetValue(), Nulls: nulls})
+	}
+
+	if len(violations) > 0 {
+		return nil, bsgostuff_domain.NewInvalidArgumentError(violations...)
+	}
+
+	if len(keys) == 0 && defaultExpression != "" {
+		keys = append(keys, bsgostuff_domain.CursorKey{Column: defaultExpression})
+	}
+	if tiebreaker != "" && !slices.ContainsFunc(keys, func(key bsgostuff_domain.CursorKey) bool { return key.Column == tiebreaker }) {
+		// Тай-брейкер следует направлению последнего ключа, чтобы подходил составной индекс
+		desc := len(keys) > 0 && keys[len(keys)-1].Desc
+		keys = append(keys, bsgostuff_domain.CursorKey{Column: tiebreaker, Desc: desc})
+	}
+
+	return keys, nil
+}
+
+// orderByClause строит ORDER BY из ключей сортировки
+func orderByClause(keys []bsgostuff_domain.CursorKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key.Column + " ASC"
+		if key.Desc {
+			parts[i] = key.Column + " DESC"
+		}
+
+		switch key.Nulls {
+		case bsgostuff_domain.NullsOrderEnumFirst:
+			parts[i] += " NULLS FIRST"
+		case bsgostuff_domain.NullsOrderEnumLast:
+			parts[i] += " NULLS LAST"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
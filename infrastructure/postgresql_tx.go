@@ -0,0 +1,201 @@
+package bsgostuff_infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Коды ошибок PostgreSQL, после которых транзакцию можно повторить
+const (
+	serializationFailureCode = "40001"
+	deadlockDetectedCode     = "40P01"
+)
+
+// TxBeginner - источник транзакций (pgxpool.Pool, pgx.Conn)
+type TxBeginner interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+// TxOptions - параметры транзакции
+type TxOptions struct {
+	IsoLevel   pgx.TxIsoLevel
+	AccessMode pgx.TxAccessMode
+	// MaxRetries - число повторов при serialization_failure и deadlock_detected
+	MaxRetries int
+	// RetryDelay - начальная задержка между повторами (удваивается с каждой попыткой)
+	RetryDelay time.Duration
+}
+
+// txKey - ключ активной транзакции в контексте
+type txKey struct{}
+
+// txState - транзакция (или точка сохранения) и ее отложенные хуки
+type txState struct {
+	tx    pgx.Tx
+	hooks []func(context.Context) error
+}
+
+// TxManager выполняет функции в транзакции, передавая ее через контекст.
+// Вложенные вызовы используют точки сохранения (SAVEPOINT) внешней транзакции.
+type TxManager struct {
+	db       TxBeginner
+	defaults TxOptions
+}
+
+func NewTxManager(db TxBeginner, defaults TxOptions) *TxManager {
+	if defaults.RetryDelay <= 0 {
+		defaults.RetryDelay = 50 * time.Millisecond
+	}
+	return &TxManager{db: db, defaults: defaults}
+}
+
+// Do выполняет fn в транзакции с параметрами по умолчанию
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.DoWithOptions(ctx, m.defaults, fn)
+}
+
+// DoWithOptions выполняет fn в транзакции с указанными параметрами.
+// Если в контексте уже есть транзакция, fn выполняется в точке сохранения,
+// а параметры (уровень изоляции, повторы) наследуются от внешней транзакции.
+// Ошибка или паника в fn откатывают транзакцию (точку сохранения).
+func (m *TxManager) DoWithOptions(ctx context.Context, opts TxOptions, fn func(ctx context.Context) error) error {
+	if parent, ok := ctx.Value(txKey{}).(*txState); ok && parent != nil {
+		return m.savepoint(ctx, parent, fn)
+	}
+
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = m.defaults.RetryDelay
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = m.run(ctx, opts, fn)
+		if err == nil || attempt >= opts.MaxRetries || !isRetriableTxError(err) {
+			return err
+		}
+
+		delay := opts.RetryDelay * time.Duration(1<<attempt)
+		delay += time.Duration(rand.Int63n(int64(opts.RetryDelay)))
+
+		slog.WarnContext(ctx, "retrying transaction", slog.Int("attempt", attempt+1), slog.Any("error", err))
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return fmt.Errorf("transaction retry canceled: %w", errors.Join(ctx.Err(), err))
+		}
+	}
+}
+
+// run выполняет одну попытку транзакции верхнего уровня
+func (m *TxManager) run(ctx context.Context, opts TxOptions, fn func(ctx context.Context) error) (err error) {
+	tx, err := m.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: opts.IsoLevel, AccessMode: opts.AccessMode})
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	state := &txState{tx: tx}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.WithoutCancel(ctx))
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		if rollbackErr := tx.Rollback(context.WithoutCancel(ctx)); rollbackErr != nil && !errors.Is(rollbackErr, pgx.ErrTxClosed) {
+			return errors.Join(err, fmt.Errorf("rollback transaction: %w", rollbackErr))
+		}
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	// Хуки выполняются вне транзакции, их ошибки не влияют на результат
+	for _, hook := range state.hooks {
+		if hookErr := hook(ctx); hookErr != nil {
+			slog.ErrorContext(ctx, "after commit hook failed", slog.Any("error", hookErr))
+		}
+	}
+
+	return nil
+}
+
+// savepoint выполняет fn во вложенной транзакции (точке сохранения)
+func (m *TxManager) savepoint(ctx context.Context, parent *txState, fn func(ctx context.Context) error) (err error) {
+	tx, err := parent.tx.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("create savepoint: %w", err)
+	}
+
+	state := &txState{tx: tx}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.WithoutCancel(ctx))
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		// Хуки откаченной точки сохранения отбрасываются
+		if rollbackErr := tx.Rollback(context.WithoutCancel(ctx)); rollbackErr != nil && !errors.Is(rollbackErr, pgx.ErrTxClosed) {
+			return errors.Join(err, fmt.Errorf("rollback savepoint: %w", rollbackErr))
+		}
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("release savepoint: %w", err)
+	}
+
+	parent.hooks = append(parent.hooks, state.hooks...)
+	return nil
+}
+
+// TxFromContext возвращает активную транзакцию из контекста
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok || state == nil {
+		return nil, false
+	}
+	return state.tx, true
+}
+
+// QuerierFromContext возвращает активную транзакцию из контекста или db, если транзакции нет.
+// Репозитории используют его, чтобы прозрачно участвовать в транзакциях TxManager.
+func QuerierFromContext(ctx context.Context, db Querier) Querier {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	return db
+}
+
+// AfterCommit регистрирует хук, который выполнится после успешного коммита
+// транзакции верхнего уровня (например, публикация событий).
+// Вне транзакции хук выполняется сразу.
+func AfterCommit(ctx context.Context, hook func(ctx context.Context) error) error {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok || state == nil {
+		return hook(ctx)
+	}
+	state.hooks = append(state.hooks, hook)
+	return nil
+}
+
+// isRetriableTxError сообщает, что транзакцию можно повторить
+func isRetriableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == serializationFailureCode || pgErr.Code == deadlockDetectedCode
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package bsgostuff_infrastructure
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	bsgostuff_domain "github.com/beavernsticks/go-stuff/domain"
+	bsgostuff_types "github.com/beavernsticks/go-stuff/types"
+)
+
+// UpdateSet содержит колонки и аргументы SET-части UPDATE-запроса
+type UpdateSet struct {
+	Columns []string
+	Args    []any
+}
+
+// settable - минимальный контракт полей bsgostuff_types, нужный для частичного обновления
+type settable interface {
+	IsSet() bool
+}
+
+var (
+	entityReflectType           = reflect.TypeFor[bsgostuff_domain.Entity]()
+	deletableEntityReflectType  = reflect.TypeFor[bsgostuff_domain.DeletableEntity]()
+	unmodifiedEntityReflectType = reflect.TypeFor[bsgostuff_domain.UnmodifiedEntity]()
+)
+
+// BuildUpdateSet обходит структуру с полями bsgostuff_types и собирает SET только из
+// установленных (IsSet) полей. Имя колонки берется из тега db, иначе из имени поля в snake_case.
+// Встроенные структуры обходятся рекурсивно. Для domain.Entity и domain.DeletableEntity
+// id и created_at не обновляются, а updated_at выставляется в текущее время
+// (и в самой структуре, если передан указатель).
+// Возвращает ErrInvalidArgument, если не установлено ни одного поля.
+func BuildUpdateSet(value any) (UpdateSet, error) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return UpdateSet{}, fmt.Errorf("%w: nil update value", bsgostuff_domain.ErrInvalidArgument)
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return UpdateSet{}, fmt.Errorf("%w: update value must be a struct, got %T", bsgostuff_domain.ErrInvalidArgument, value)
+	}
+
+	var set UpdateSet
+	var updatedAt []reflect.Value
+	collectUpdateFields(v, false, &set, &updatedAt)
+
+	if len(set.Columns) == 0 {
+		return UpdateSet{}, fmt.Errorf("%w: no fields set for update", bsgostuff_domain.ErrInvalidArgument)
+	}
+
+	if len(updatedAt) > 0 {
+		now := bsgostuff_types.NewCurrentTimestamp()
+		for _, field := range updatedAt {
+			if field.CanSet() {
+				field.Set(reflect.ValueOf(now))
+			}
+		}
+		set.Columns = append(set.Columns, "updated_at")
+		set.Args = append(set.Args, now)
+	}
+
+	return set, nil
+}
+
+// SQL возвращает фрагмент "col1 = $n, col2 = $n+1, ..." с нумерацией плейсхолдеров от startIndex
+func (s UpdateSet) SQL(startIndex int) string {
+	parts := make([]string, len(s.Columns))
+	for i, column := range s.Columns {
+		parts[i] = fmt.Sprintf("%s = $%d", column, startIndex+i)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// BuildUpdateQuery строит запрос "UPDATE table SET ... WHERE where".
+// Плейсхолдеры в where нумеруются с $1 и соответствуют whereArgs,
+// плейсхолдеры SET идут следом, например:
+//
+//	query, args, err := BuildUpdateQuery("users", user, "id = $1", user.ID)
+func BuildUpdateQuery(table string, value any, where string, whereArgs ...any) (string, []any, error) {
+	set, err := BuildUpdateSet(value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", table, set.SQL(len(whereArgs)+1), where)
+	args := append(append(make([]any, 0, len(whereArgs)+len(set.Args)), whereArgs...), set.Args...)
+
+	return query, args, nil
+}
+
+// collectUpdateFields рекурсивно собирает установленные поля структуры.
+// managed включается внутри доменных сущностей, где служебные колонки обрабатываются особо.
+func collectUpdateFields(v reflect.Value, managed bool, set *UpdateSet, updatedAt *[]reflect.Value) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		column, ok := columnName(sf)
+		if !ok {
+			continue
+		}
+
+		fv := v.Field(i)
+
+		if _, ok := fv.Interface().(settable); ok {
+			// nil-указатель на тип bsgostuff_types - поле не установлено
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			s := fv.Interface().(settable)
+			if managed {
+				switch column {
+				case "id", "created_at":
+					continue
+				case "updated_at":
+					*updatedAt = append(*updatedAt, fv)
+					continue
+				}
+			}
+			if s.IsSet() {
+				set.Columns = append(set.Columns, column)
+				set.Args = append(set.Args, fv.Interface())
+			}
+			continue
+		}
+
+		nested := fv
+		if nested.Kind() == reflect.Pointer {
+			if nested.IsNil() {
+				continue
+			}
+			nested = nested.Elem()
+		}
+		if nested.Kind() != reflect.Struct {
+			continue
+		}
+
+		switch nested.Type() {
+		case entityReflectType, deletableEntityReflectType, unmodifiedEntityReflectType:
+			collectUpdateFields(nested, true, set, updatedAt)
+		default:
+			if sf.Anonymous {
+				collectUpdateFields(nested, managed, set, updatedAt)
+			}
+		}
+	}
+}
+
+// columnName возвращает имя колонки для поля структуры:
+// значение тега db (до запятой) или имя поля в snake_case.
+// Поля с тегом db:"-" пропускаются.
+func columnName(sf reflect.StructField) (string, bool) {
+	tag := sf.Tag.Get("db")
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return toSnakeCase(sf.Name), true
+}
+
+// toSnakeCase преобразует имя поля Go в snake_case: UserID -> user_id, CreatedAt -> created_at
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
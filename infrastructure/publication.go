@@ -0,0 +1,355 @@
+package bsgostuff_infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	bsgostuff_domain "github.com/beavernsticks/go-stuff/domain"
+	bsgostuff_events "github.com/beavernsticks/go-stuff/events"
+	bsgostuff_types "github.com/beavernsticks/go-stuff/types"
+	"github.com/jackc/pgx/v5"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultPublicationTopic - топик событий bsgostuff_events.PublicationChanged по умолчанию
+const DefaultPublicationTopic = "publication.changed"
+
+// PublicationHistoryTableSQL возвращает DDL таблицы истории публикации (для миграций)
+func PublicationHistoryTableSQL(table string) string {
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+	id          BIGSERIAL PRIMARY KEY,
+	entity_id   UUID        NOT NULL,
+	from_status TEXT        NOT NULL,
+	to_status   TEXT        NOT NULL,
+	actor       TEXT,
+	reason      TEXT,
+	scheduled   BOOLEAN     NOT NULL DEFAULT FALSE,
+	created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS %[2]s_entity_idx ON %[1]s (entity_id, id);`, table, sanitizeIdentifier(table))
+}
+
+// PublicationEventPublisher - получатель событий публикации (реализуется NATSBroker)
+type PublicationEventPublisher interface {
+	Publish(ctx context.Context, topic string, msg proto.Message, opts ...nats.PubOpt) error
+}
+
+// PublicationConfig - параметры PublicationService
+type PublicationConfig struct {
+	// Table - таблица сущностей с колонками domain.Publication
+	Table string
+	// HistoryTable - таблица истории переходов (по умолчанию <Table>_publication_history)
+	HistoryTable string
+	// Resource - тип ресурса в событиях и ошибках (по умолчанию Table)
+	Resource string
+	// Topic - топик событий (по умолчанию DefaultPublicationTopic)
+	Topic string
+	// Workflow - допустимые переходы (по умолчанию domain.NewPublicationWorkflow)
+	Workflow *bsgostuff_domain.PublicationWorkflow
+}
+
+// PublicationService выполняет переходы статуса публикации по PublicationWorkflow:
+// обновляет сущность, пишет историю и после коммита публикует событие PublicationChanged.
+// Переходы выполняются в транзакции из контекста, если она открыта через TxManager.
+type PublicationService struct {
+	db     Querier
+	tx     *TxManager
+	events PublicationEventPublisher
+	cfg    PublicationConfig
+}
+
+// NewPublicationService создает сервис; events может быть nil, тогда события не публикуются
+func NewPublicationService(db Querier, tx *TxManager, events PublicationEventPublisher, cfg PublicationConfig) (*PublicationService, error) {
+	if cfg.Table == "" {
+		return nil, fmt.Errorf("%w: table name is required", bsgostuff_domain.ErrInvalidArgument)
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("%w: transaction manager is required", bsgostuff_domain.ErrInvalidArgument)
+	}
+	if cfg.HistoryTable == "" {
+		cfg.HistoryTable = cfg.Table + "_publication_history"
+	}
+	if cfg.Resource == "" {
+		cfg.Resource = cfg.Table
+	}
+	if cfg.Topic == "" {
+		cfg.Topic = DefaultPublicationTopic
+	}
+	if cfg.Workflow == nil {
+		cfg.Workflow = bsgostuff_domain.NewPublicationWorkflow()
+	}
+
+	return &PublicationService{db: db, tx: tx, events: events, cfg: cfg}, nil
+}
+
+func MustNewPublicationService(db Querier, tx *TxManager, events PublicationEventPublisher, cfg PublicationConfig) *PublicationService {
+	service, err := NewPublicationService(db, tx, events, cfg)
+	if err != nil {
+		panic(fmt.Errorf("failed to initialize publication service: %w", err))
+	}
+	return service
+}
+
+// Transition переводит сущность id в статус to от имени actor
+func (s *PublicationService) Transition(ctx context.Context, id bsgostuff_types.ID, to bsgostuff_domain.PublicationStatusEnum, actor, reason string) (bsgostuff_domain.PublicationChange, error) {
+	change := bsgostuff_domain.PublicationChange{
+		EntityID:  id,
+		To:        bsgostuff_types.NewEnum(to),
+		Scheduled: bsgostuff_types.NewBool(false),
+	}
+	if actor != "" {
+		change.Actor = bsgostuff_types.NewString(actor)
+	}
+	if reason != "" {
+		change.Reason = bsgostuff_types.NewString(reason)
+	}
+
+	err := s.tx.Do(ctx, func(ctx context.Context) (err error) {
+		change, err = s.transition(ctx, change)
+		return err
+	})
+	return change, err
+}
+
+// Publish публикует сущность
+func (s *PublicationService) Publish(ctx context.Context, id bsgostuff_types.ID, actor string) (bsgostuff_domain.PublicationChange, error) {
+	return s.Transition(ctx, id, bsgostuff_domain.PublicationStatusEnumPublished, actor, "")
+}
+
+// Unpublish снимает сущность с публикации
+func (s *PublicationService) Unpublish(ctx context.Context, id bsgostuff_types.ID, actor string) (bsgostuff_domain.PublicationChange, error) {
+	return s.Transition(ctx, id, bsgostuff_domain.PublicationStatusEnumUnpublished, actor, "")
+}
+
+// transition выполняет переход в текущей транзакции
+func (s *PublicationService) transition(ctx context.Context, change bsgostuff_domain.PublicationChange) (bsgostuff_domain.PublicationChange, error) {
+	q := QuerierFromContext(ctx, s.db)
+
+	query := fmt.Sprintf("SELECT status FROM %s WHERE id = $1 FOR UPDATE", s.cfg.Table)
+	if err := q.QueryRow(ctx, query, change.EntityID).Scan(&change.From); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return change, bsgostuff_domain.NewNotFoundError(s.cfg.Resource, change.EntityID.String()).WithCause(err)
+		}
+		return change, fmt.Errorf("publication select failed: %w", err)
+	}
+
+	if err := s.cfg.Workflow.Check(ctx, change); err != nil {
+		return change, err
+	}
+	return s.apply(ctx, change)
+}
+
+// apply записывает разрешенный переход, его историю и событие в текущей транзакции
+func (s *PublicationService) apply(ctx context.Context, change bsgostuff_domain.PublicationChange) (bsgostuff_domain.PublicationChange, error) {
+	q := QuerierFromContext(ctx, s.db)
+	change.At = bsgostuff_types.NewCurrentTimestamp()
+
+	// Публикация выполняет план публикации, снятие с публикации - план снятия
+	published := change.To.GetValue() == bsgostuff_domain.PublicationStatusEnumPublished
+	query := fmt.Sprintf(`UPDATE %s SET
+	status = $2,
+	published_at = CASE WHEN $3 THEN $4 ELSE published_at END,
+	publish_at = CASE WHEN $3 THEN NULL ELSE publish_at END,
+	unpublish_at = CASE WHEN $3 THEN unpublish_at ELSE NULL END
+WHERE id = $1`, s.cfg.Table)
+	if _, err := q.Exec(ctx, query, change.EntityID, change.To, published, change.At); err != nil {
+		return change, fmt.Errorf("publication update failed: %w", err)
+	}
+
+	query = fmt.Sprintf(`INSERT INTO %s (entity_id, from_status, to_status, actor, reason, scheduled, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)`, s.cfg.HistoryTable)
+	if _, err := q.Exec(ctx, query, change.EntityID, change.From, change.To, change.Actor, change.Reason, change.Scheduled, change.At); err != nil {
+		return change, fmt.Errorf("publication history insert failed: %w", err)
+	}
+
+	if s.events == nil {
+		return change, nil
+	}
+	event := &bsgostuff_events.PublicationChanged{
+		Resource:   s.cfg.Resource,
+		EntityId:   change.EntityID.String(),
+		FromStatus: string(change.From.GetValue()),
+		ToStatus:   string(change.To.GetValue()),
+		Actor:      change.Actor.GetValue(),
+		Reason:     change.Reason.GetValue(),
+		Scheduled:  change.Scheduled.GetValue(),
+		Timestamp:  change.At.RFC3339(),
+	}
+	return change, AfterCommit(ctx, func(ctx context.Context) error {
+		return s.events.Publish(ctx, s.cfg.Topic, event)
+	})
+}
+
+// Schedule задает время публикации и снятия с публикации (см. domain.PublicationSchedule)
+func (s *PublicationService) Schedule(ctx context.Context, id bsgostuff_types.ID, schedule bsgostuff_domain.PublicationSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET
+	publish_at = CASE WHEN $2 THEN $3 ELSE publish_at END,
+	unpublish_at = CASE WHEN $4 THEN $5 ELSE unpublish_at END
+WHERE id = $1`, s.cfg.Table)
+	tag, err := QuerierFromContext(ctx, s.db).Exec(ctx, query, id,
+		schedule.PublishAt.IsSet(), schedule.PublishAt, schedule.UnpublishAt.IsSet(), schedule.UnpublishAt)
+	if err != nil {
+		return fmt.Errorf("publication schedule failed: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return bsgostuff_domain.NewNotFoundError(s.cfg.Resource, id.String())
+	}
+
+	return nil
+}
+
+// History возвращает переходы сущности в хронологическом порядке
+func (s *PublicationService) History(ctx context.Context, id bsgostuff_types.ID) ([]bsgostuff_domain.PublicationChange, error) {
+	query := fmt.Sprintf(`SELECT entity_id, from_status, to_status, actor, reason, scheduled, created_at
+FROM %s WHERE entity_id = $1 ORDER BY id`, s.cfg.HistoryTable)
+
+	rows, err := QuerierFromContext(ctx, s.db).Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("publication history select failed: %w", err)
+	}
+
+	changes, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (bsgostuff_domain.PublicationChange, error) {
+		var change bsgostuff_domain.PublicationChange
+		err := row.Scan(&change.EntityID, &change.From, &change.To, &change.Actor, &change.Reason, &change.Scheduled, &change.At)
+		return change, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("publication history scan failed: %w", err)
+	}
+
+	return changes, nil
+}
+
+// PublicationSchedulerConfig - параметры PublicationScheduler
+type PublicationSchedulerConfig struct {
+	BatchSize    int
+	PollInterval time.Duration
+}
+
+// PublicationScheduler выполняет запланированные переходы, время которых наступило.
+// Несколько экземпляров могут работать параллельно благодаря FOR UPDATE SKIP LOCKED.
+type PublicationScheduler struct {
+	service *PublicationService
+	cfg     PublicationSchedulerConfig
+}
+
+func NewPublicationScheduler(service *PublicationService, cfg PublicationSchedulerConfig) *PublicationScheduler {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 10 * time.Second
+	}
+	return &PublicationScheduler{service: service, cfg: cfg}
+}
+
+// Run выполняет запланированные переходы, пока не будет отменен контекст
+func (s *PublicationScheduler) Run(ctx context.Context) error {
+	for {
+		processed, err := s.ProcessDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "publication scheduler batch failed", slog.Any("error", err))
+		}
+
+		// Полная пачка - вероятно, есть еще сущности, продолжаем без паузы
+		if err == nil && processed == s.cfg.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.cfg.PollInterval):
+		}
+	}
+}
+
+// ProcessDue выполняет одну пачку наступивших переходов и возвращает число обработанных сущностей.
+// Переход, отклоненный PublicationWorkflow (доменной ошибкой перехода или guard-а), снимается с расписания,
+// чтобы не повторяться. Прочие ошибки проверки (сбой guard-а, таймаут) не снимают расписание:
+// сущность пропускается и повторяется при следующем опросе. Ошибки SQL прерывают пачку.
+func (s *PublicationScheduler) ProcessDue(ctx context.Context) (processed int, err error) {
+	err = s.service.tx.Do(ctx, func(ctx context.Context) error {
+		q := QuerierFromContext(ctx, s.service.db)
+
+		query := fmt.Sprintf(`SELECT id, status FROM %s
+WHERE (publish_at <= now() AND status <> $1) OR (unpublish_at <= now() AND status = $1)
+ORDER BY id
+LIMIT $2
+FOR UPDATE SKIP LOCKED`, s.service.cfg.Table)
+
+		rows, err := q.Query(ctx, query, string(bsgostuff_domain.PublicationStatusEnumPublished), s.cfg.BatchSize)
+		if err != nil {
+			return fmt.Errorf("publication due select failed: %w", err)
+		}
+		due, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (bsgostuff_domain.PublicationChange, error) {
+			var change bsgostuff_domain.PublicationChange
+			err := row.Scan(&change.EntityID, &change.From)
+			return change, err
+		})
+		if err != nil {
+			return fmt.Errorf("publication due scan failed: %w", err)
+		}
+
+		for _, change := range due {
+			change.To = bsgostuff_types.NewEnum(bsgostuff_domain.PublicationStatusEnumPublished)
+			column := "publish_at"
+			if change.From.GetValue() == bsgostuff_domain.PublicationStatusEnumPublished {
+				change.To = bsgostuff_types.NewEnum(bsgostuff_domain.PublicationStatusEnumUnpublished)
+				column = "unpublish_at"
+			}
+			change.Scheduled = bsgostuff_types.NewBool(true)
+
+			// Строка уже заблокирована выборкой, поэтому переход проверяется без повторного чтения.
+			// Guard-ы выполняются в точке сохранения: их запросы не должны прерывать транзакцию пачки.
+			rejectErr := s.service.tx.Do(ctx, func(ctx context.Context) error {
+				return s.service.cfg.Workflow.Check(ctx, change)
+			})
+			if rejectErr != nil {
+				if _, ok := bsgostuff_domain.AsError(rejectErr); !ok {
+					slog.WarnContext(ctx, "scheduled publication transition check failed, will retry",
+						slog.String("resource", s.service.cfg.Resource),
+						slog.String("id", change.EntityID.String()),
+						slog.String("to", string(change.To.GetValue())),
+						slog.Any("error", rejectErr),
+					)
+					// не учитывается в processed, чтобы Run не повторял пачку без паузы
+					continue
+				}
+
+				slog.WarnContext(ctx, "scheduled publication transition rejected",
+					slog.String("resource", s.service.cfg.Resource),
+					slog.String("id", change.EntityID.String()),
+					slog.String("to", string(change.To.GetValue())),
+					slog.Any("error", rejectErr),
+				)
+
+				query := fmt.Sprintf("UPDATE %s SET %s = NULL WHERE id = $1", s.service.cfg.Table, column)
+				if _, err := q.Exec(ctx, query, change.EntityID); err != nil {
+					return fmt.Errorf("publication schedule reset failed: %w", err)
+				}
+				processed++
+				continue
+			}
+
+			if _, err := s.service.apply(ctx, change); err != nil {
+				return err
+			}
+			processed++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return processed, nil
+}
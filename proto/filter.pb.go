@@ -0,0 +1,425 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: proto/definitions/filter.proto
+
+package bsgostuff_proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FilterOperator int32
+
+const (
+	FilterOperator_FILTER_OPERATOR_UNKNOWN     FilterOperator = 0
+	FilterOperator_FILTER_OPERATOR_EQ          FilterOperator = 1
+	FilterOperator_FILTER_OPERATOR_NE          FilterOperator = 2
+	FilterOperator_FILTER_OPERATOR_IN          FilterOperator = 3
+	FilterOperator_FILTER_OPERATOR_NOT_IN      FilterOperator = 4
+	FilterOperator_FILTER_OPERATOR_LT          FilterOperator = 5
+	FilterOperator_FILTER_OPERATOR_LTE         FilterOperator = 6
+	FilterOperator_FILTER_OPERATOR_GT          FilterOperator = 7
+	FilterOperator_FILTER_OPERATOR_GTE         FilterOperator = 8
+	FilterOperator_FILTER_OPERATOR_BETWEEN     FilterOperator = 9
+	FilterOperator_FILTER_OPERATOR_LIKE        FilterOperator = 10
+	FilterOperator_FILTER_OPERATOR_ILIKE       FilterOperator = 11
+	FilterOperator_FILTER_OPERATOR_IS_NULL     FilterOperator = 12
+	FilterOperator_FILTER_OPERATOR_IS_NOT_NULL FilterOperator = 13
+)
+
+// Enum value maps for FilterOperator.
+var (
+	FilterOperator_name = map[int32]string{
+		0:  "FILTER_OPERATOR_UNKNOWN",
+		1:  "FILTER_OPERATOR_EQ",
+		2:  "FILTER_OPERATOR_NE",
+		3:  "FILTER_OPERATOR_IN",
+		4:  "FILTER_OPERATOR_NOT_IN",
+		5:  "FILTER_OPERATOR_LT",
+		6:  "FILTER_OPERATOR_LTE",
+		7:  "FILTER_OPERATOR_GT",
+		8:  "FILTER_OPERATOR_GTE",
+		9:  "FILTER_OPERATOR_BETWEEN",
+		10: "FILTER_OPERATOR_LIKE",
+		11: "FILTER_OPERATOR_ILIKE",
+		12: "FILTER_OPERATOR_IS_NULL",
+		13: "FILTER_OPERATOR_IS_NOT_NULL",
+	}
+	FilterOperator_value = map[string]int32{
+		"FILTER_OPERATOR_UNKNOWN":     0,
+		"FILTER_OPERATOR_EQ":          1,
+		"FILTER_OPERATOR_NE":          2,
+		"FILTER_OPERATOR_IN":          3,
+		"FILTER_OPERATOR_NOT_IN":      4,
+		"FILTER_OPERATOR_LT":          5,
+		"FILTER_OPERATOR_LTE":         6,
+		"FILTER_OPERATOR_GT":          7,
+		"FILTER_OPERATOR_GTE":         8,
+		"FILTER_OPERATOR_BETWEEN":     9,
+		"FILTER_OPERATOR_LIKE":        10,
+		"FILTER_OPERATOR_ILIKE":       11,
+		"FILTER_OPERATOR_IS_NULL":     12,
+		"FILTER_OPERATOR_IS_NOT_NULL": 13,
+	}
+)
+
+func (x FilterOperator) Enum() *FilterOperator {
+	p := new(FilterOperator)
+	*p = x
+	return p
+}
+
+func (x FilterOperator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FilterOperator) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_definitions_filter_proto_enumTypes[0].Descriptor()
+}
+
+func (FilterOperator) Type() protoreflect.EnumType {
+	return &file_proto_definitions_filter_proto_enumTypes[0]
+}
+
+func (x FilterOperator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FilterOperator.Descriptor instead.
+func (FilterOperator) EnumDescriptor() ([]byte, []int) {
+	return file_proto_definitions_filter_proto_rawDescGZIP(), []int{0}
+}
+
+type FilterCondition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Operator      FilterOperator         `protobuf:"varint,2,opt,name=operator,proto3,enum=bsgostuff.FilterOperator" json:"operator,omitempty"`
+	Values        []*structpb.Value      `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterCondition) Reset() {
+	*x = FilterCondition{}
+	mi := &file_proto_definitions_filter_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterCondition) ProtoMessage() {}
+
+func (x *FilterCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_definitions_filter_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterCondition.ProtoReflect.Descriptor instead.
+func (*FilterCondition) Descriptor() ([]byte, []int) {
+	return file_proto_definitions_filter_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FilterCondition) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FilterCondition) GetOperator() FilterOperator {
+	if x != nil {
+		return x.Operator
+	}
+	return FilterOperator_FILTER_OPERATOR_UNKNOWN
+}
+
+func (x *FilterCondition) GetValues() []*structpb.Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type FilterGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filters       []*Filter              `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterGroup) Reset() {
+	*x = FilterGroup{}
+	mi := &file_proto_definitions_filter_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterGroup) ProtoMessage() {}
+
+func (x *FilterGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_definitions_filter_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterGroup.ProtoReflect.Descriptor instead.
+func (*FilterGroup) Descriptor() ([]byte, []int) {
+	return file_proto_definitions_filter_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FilterGroup) GetFilters() []*Filter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+type Filter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Expression:
+	//
+	//	*Filter_Condition
+	//	*Filter_And
+	//	*Filter_Or
+	//	*Filter_Not
+	Expression    isFilter_Expression `protobuf_oneof:"expression"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filter) Reset() {
+	*x = Filter{}
+	mi := &file_proto_definitions_filter_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filter) ProtoMessage() {}
+
+func (x *Filter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_definitions_filter_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filter.ProtoReflect.Descriptor instead.
+func (*Filter) Descriptor() ([]byte, []int) {
+	return file_proto_definitions_filter_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Filter) GetExpression() isFilter_Expression {
+	if x != nil {
+		return x.Expression
+	}
+	return nil
+}
+
+func (x *Filter) GetCondition() *FilterCondition {
+	if x != nil {
+		if x, ok := x.Expression.(*Filter_Condition); ok {
+			return x.Condition
+		}
+	}
+	return nil
+}
+
+func (x *Filter) GetAnd() *FilterGroup {
+	if x != nil {
+		if x, ok := x.Expression.(*Filter_And); ok {
+			return x.And
+		}
+	}
+	return nil
+}
+
+func (x *Filter) GetOr() *FilterGroup {
+	if x != nil {
+		if x, ok := x.Expression.(*Filter_Or); ok {
+			return x.Or
+		}
+	}
+	return nil
+}
+
+func (x *Filter) GetNot() *Filter {
+	if x != nil {
+		if x, ok := x.Expression.(*Filter_Not); ok {
+			return x.Not
+		}
+	}
+	return nil
+}
+
+type isFilter_Expression interface {
+	isFilter_Expression()
+}
+
+type Filter_Condition struct {
+	Condition *FilterCondition `protobuf:"bytes,1,opt,name=condition,proto3,oneof"`
+}
+
+type Filter_And struct {
+	And *FilterGroup `protobuf:"bytes,2,opt,name=and,proto3,oneof"`
+}
+
+type Filter_Or struct {
+	Or *FilterGroup `protobuf:"bytes,3,opt,name=or,proto3,oneof"`
+}
+
+type Filter_Not struct {
+	Not *Filter `protobuf:"bytes,4,opt,name=not,proto3,oneof"`
+}
+
+func (*Filter_Condition) isFilter_Expression() {}
+
+func (*Filter_And) isFilter_Expression() {}
+
+func (*Filter_Or) isFilter_Expression() {}
+
+func (*Filter_Not) isFilter_Expression() {}
+
+var File_proto_definitions_filter_proto protoreflect.FileDescriptor
+
+const file_proto_definitions_filter_proto_rawDesc = "" +
+	"\n" +
+	"\x1eproto/definitions/filter.proto\x12\tbsgostuff\x1a\x1cgoogle/protobuf/struct.proto\"\x8e\x01\n" +
+	"\x0fFilterCondition\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x125\n" +
+	"\boperator\x18\x02 \x01(\x0e2\x19.bsgostuff.FilterOperatorR\boperator\x12.\n" +
+	"\x06values\x18\x03 \x03(\v2\x16.google.protobuf.ValueR\x06values\":\n" +
+	"\vFilterGroup\x12+\n" +
+	"\afilters\x18\x01 \x03(\v2\x11.bsgostuff.FilterR\afilters\"\xcf\x01\n" +
+	"\x06Filter\x12:\n" +
+	"\tcondition\x18\x01 \x01(\v2\x1a.bsgostuff.FilterConditionH\x00R\tcondition\x12*\n" +
+	"\x03and\x18\x02 \x01(\v2\x16.bsgostuff.FilterGroupH\x00R\x03and\x12(\n" +
+	"\x02or\x18\x03 \x01(\v2\x16.bsgostuff.FilterGroupH\x00R\x02or\x12%\n" +
+	"\x03not\x18\x04 \x01(\v2\x11.bsgostuff.FilterH\x00R\x03notB\f\n" +
+	"\n" +
+	"expression*\x83\x03\n" +
+	"\x0eFilterOperator\x12\x1b\n" +
+	"\x17FILTER_OPERATOR_UNKNOWN\x10\x00\x12\x16\n" +
+	"\x12FILTER_OPERATOR_EQ\x10\x01\x12\x16\n" +
+	"\x12FILTER_OPERATOR_NE\x10\x02\x12\x16\n" +
+	"\x12FILTER_OPERATOR_IN\x10\x03\x12\x1a\n" +
+	"\x16FILTER_OPERATOR_NOT_IN\x10\x04\x12\x16\n" +
+	"\x12FILTER_OPERATOR_LT\x10\x05\x12\x17\n" +
+	"\x13FILTER_OPERATOR_LTE\x10\x06\x12\x16\n" +
+	"\x12FILTER_OPERATOR_GT\x10\a\x12\x17\n" +
+	"\x13FILTER_OPERATOR_GTE\x10\b\x12\x1b\n" +
+	"\x17FILTER_OPERATOR_BETWEEN\x10\t\x12\x18\n" +
+	"\x14FILTER_OPERATOR_LIKE\x10\n" +
+	"\x12\x19\n" +
+	"\x15FILTER_OPERATOR_ILIKE\x10\v\x12\x1b\n" +
+	"\x17FILTER_OPERATOR_IS_NULL\x10\f\x12\x1f\n" +
+	"\x1bFILTER_OPERATOR_IS_NOT_NULL\x10\rB9Z7github.com/beavernsticks/go-stuff/proto;bsgostuff_protob\x06proto3"
+
+var (
+	file_proto_definitions_filter_proto_rawDescOnce sync.Once
+	file_proto_definitions_filter_proto_rawDescData []byte
+)
+
+func file_proto_definitions_filter_proto_rawDescGZIP() []byte {
+	file_proto_definitions_filter_proto_rawDescOnce.Do(func() {
+		file_proto_definitions_filter_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_definitions_filter_proto_rawDesc), len(file_proto_definitions_filter_proto_rawDesc)))
+	})
+	return file_proto_definitions_filter_proto_rawDescData
+}
+
+var file_proto_definitions_filter_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_definitions_filter_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_definitions_filter_proto_goTypes = []any{
+	(FilterOperator)(0),     // 0: bsgostuff.FilterOperator
+	(*FilterCondition)(nil), // 1: bsgostuff.FilterCondition
+	(*FilterGroup)(nil),     // 2: bsgostuff.FilterGroup
+	(*Filter)(nil),          // 3: bsgostuff.Filter
+	(*structpb.Value)(nil),  // 4: google.protobuf.Value
+}
+var file_proto_definitions_filter_proto_depIdxs = []int32{
+	0, // 0: bsgostuff.FilterCondition.operator:type_name -> bsgostuff.FilterOperator
+	4, // 1: bsgostuff.FilterCondition.values:type_name -> google.protobuf.Value
+	3, // 2: bsgostuff.FilterGroup.filters:type_name -> bsgostuff.Filter
+	1, // 3: bsgostuff.Filter.condition:type_name -> bsgostuff.FilterCondition
+	2, // 4: bsgostuff.Filter.and:type_name -> bsgostuff.FilterGroup
+	2, // 5: bsgostuff.Filter.or:type_name -> bsgostuff.FilterGroup
+	3, // 6: bsgostuff.Filter.not:type_name -> bsgostuff.Filter
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_proto_definitions_filter_proto_init() }
+func file_proto_definitions_filter_proto_init() {
+	if File_proto_definitions_filter_proto != nil {
+		return
+	}
+	file_proto_definitions_filter_proto_msgTypes[2].OneofWrappers = []any{
+		(*Filter_Condition)(nil),
+		(*Filter_And)(nil),
+		(*Filter_Or)(nil),
+		(*Filter_Not)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_definitions_filter_proto_rawDesc), len(file_proto_definitions_filter_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_definitions_filter_proto_goTypes,
+		DependencyIndexes: file_proto_definitions_filter_proto_depIdxs,
+		EnumInfos:         file_proto_definitions_filter_proto_enumTypes,
+		MessageInfos:      file_proto_definitions_filter_proto_msgTypes,
+	}.Build()
+	File_proto_definitions_filter_proto = out.File
+	file_proto_definitions_filter_proto_goTypes = nil
+	file_proto_definitions_filter_proto_depIdxs = nil
+}
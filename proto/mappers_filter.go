@@ -0,0 +1,178 @@
+package bsgostuff_proto
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	bsgostuff_domain "github.com/beavernsticks/go-stuff/domain"
+	bsgostuff_types "github.com/beavernsticks/go-stuff/types"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// filterOperatorPrefix - префикс значений FilterOperator; остаток совпадает с FilterOperatorEnum
+const filterOperatorPrefix = "FILTER_OPERATOR_"
+
+func ToFilterProto(value *bsgostuff_domain.Filter) *Filter {
+	_filter := bsgostuff_types.DerefZero(value)
+
+	switch {
+	case len(_filter.And) > 0:
+		return &Filter{Expression: &Filter_And{And: toFilterGroupProto(_filter.And)}}
+	case len(_filter.Or) > 0:
+		return &Filter{Expression: &Filter_Or{Or: toFilterGroupProto(_filter.Or)}}
+	case _filter.Not != nil:
+		return &Filter{Expression: &Filter_Not{Not: ToFilterProto(_filter.Not)}}
+	case _filter.IsEmpty():
+		return &Filter{}
+	}
+
+	_values := make([]*structpb.Value, len(_filter.Values))
+	for i, raw := range _filter.Values {
+		_value, err := structpb.NewValue(raw)
+		if err != nil {
+			_value = structpb.NewStringValue(fmt.Sprint(raw))
+		}
+		_values[i] = _value
+	}
+
+	return &Filter{Expression: &Filter_Condition{Condition: &FilterCondition{
+		Field:    _filter.Field.GetValue(),
+		Operator: ToFilterOperatorProto(_filter.Operator.GetValue()),
+		Values:   _values,
+	}}}
+}
+
+func toFilterGroupProto(values []bsgostuff_domain.Filter) *FilterGroup {
+	_filters := make([]*Filter, len(values))
+	for i := range values {
+		_filters[i] = ToFilterProto(&values[i])
+	}
+	return &FilterGroup{Filters: _filters}
+}
+
+func FromFilterProto(value *Filter) bsgostuff_domain.Filter {
+	switch expression := value.GetExpression().(type) {
+	case *Filter_And:
+		return bsgostuff_domain.Filter{And: fromFilterGroupProto(expression.And)}
+	case *Filter_Or:
+		return bsgostuff_domain.Filter{Or: fromFilterGroupProto(expression.Or)}
+	case *Filter_Not:
+		_not := FromFilterProto(expression.Not)
+		return bsgostuff_domain.Filter{Not: &_not}
+	case *Filter_Condition:
+		_values := make([]any, len(expression.Condition.GetValues()))
+		for i, _value := range expression.Condition.GetValues() {
+			_values[i] = fromFilterValueProto(_value)
+		}
+
+		return bsgostuff_domain.Filter{
+			Field:    bsgostuff_types.NewString(expression.Condition.GetField()),
+			Operator: bsgostuff_types.NewEnum(FromFilterOperatorProto(expression.Condition.GetOperator())),
+			Values:   _values,
+		}
+	default:
+		return bsgostuff_domain.Filter{}
+	}
+}
+
+func (value *Filter) FromProto() bsgostuff_domain.Filter {
+	return FromFilterProto(value)
+}
+
+func fromFilterGroupProto(value *FilterGroup) []bsgostuff_domain.Filter {
+	_filters := make([]bsgostuff_domain.Filter, 0, len(value.GetFilters()))
+	for _, _filter := range value.GetFilters() {
+		_filters = append(_filters, FromFilterProto(_filter))
+	}
+	return _filters
+}
+
+// fromFilterValueProto возвращает значение в виде, который принимают типы bsgostuff_types:
+// целые числа - int64, остальные числа - float64
+func fromFilterValueProto(value *structpb.Value) any {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_NumberValue:
+		if kind.NumberValue == math.Trunc(kind.NumberValue) && math.Abs(kind.NumberValue) < 1<<53 {
+			return int64(kind.NumberValue)
+		}
+		return kind.NumberValue
+	case nil:
+		return nil
+	default:
+		return value.AsInterface()
+	}
+}
+
+func ToFilterOperatorProto(value bsgostuff_domain.FilterOperatorEnum) FilterOperator {
+	return FilterOperator(FilterOperator_value[filterOperatorPrefix+string(value)])
+}
+
+func FromFilterOperatorProto(value FilterOperator) bsgostuff_domain.FilterOperatorEnum {
+	if value == FilterOperator_FILTER_OPERATOR_UNKNOWN {
+		return bsgostuff_domain.FilterOperatorEnumUnknown
+	}
+	return bsgostuff_domain.FilterOperatorEnum(strings.TrimPrefix(value.String(), filterOperatorPrefix))
+}
+
+// FilterInput - входной тип фильтра GraphQL; значения передаются строками
+// и приводятся к типам полей при проверке:
+//
+//	enum FilterOperator { EQ NE IN NOT_IN LT LTE GT GTE BETWEEN LIKE ILIKE IS_NULL IS_NOT_NULL }
+//	input FilterInput {
+//	  field: String
+//	  operator: FilterOperator
+//	  values: [String]
+//	  and: [FilterInput!]
+//	  or: [FilterInput!]
+//	  not: FilterInput
+//	}
+type FilterInput struct {
+	Field    *string        `json:"field"`
+	Operator *string        `json:"operator"`
+	Values   []*string      `json:"values"`
+	And      []*FilterInput `json:"and"`
+	Or       []*FilterInput `json:"or"`
+	Not      *FilterInput   `json:"not"`
+}
+
+func FromFilterInput(value *FilterInput) bsgostuff_domain.Filter {
+	if value == nil {
+		return bsgostuff_domain.Filter{}
+	}
+
+	_filter := bsgostuff_domain.Filter{
+		And: fromFilterInputs(value.And),
+		Or:  fromFilterInputs(value.Or),
+	}
+	if value.Not != nil {
+		_not := FromFilterInput(value.Not)
+		_filter.Not = &_not
+	}
+	if value.Field != nil {
+		_filter.Field = bsgostuff_types.NewString(*value.Field)
+	}
+	if value.Operator != nil {
+		_filter.Operator = bsgostuff_types.NewEnum(bsgostuff_domain.FilterOperatorEnum(*value.Operator))
+	}
+	for _, _value := range value.Values {
+		if _value == nil {
+			_filter.Values = append(_filter.Values, nil)
+		} else {
+			_filter.Values = append(_filter.Values, *_value)
+		}
+	}
+
+	return _filter
+}
+
+func fromFilterInputs(values []*FilterInput) []bsgostuff_domain.Filter {
+	if len(values) == 0 {
+		return nil
+	}
+	_filters := make([]bsgostuff_domain.Filter, 0, len(values))
+	for _, value := range values {
+		_filters = append(_filters, FromFilterInput(value))
+	}
+	return _filters
+}
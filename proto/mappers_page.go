@@ -0,0 +1,140 @@
+package bsgostuff_proto
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	bsgostuff_domain "github.com/beavernsticks/go-stuff/domain"
+	bsgostuff_types "github.com/beavernsticks/go-stuff/types"
+)
+
+// ToPageInfoProto возвращает параметры страницы; элементы сервис кладет в ответ сам:
+// &ListUsersResponse{Items: items, PageInfo: ToPageInfoProto(page)}
+func ToPageInfoProto[T any](page bsgostuff_domain.Page[T]) *PageInfo {
+	return &PageInfo{
+		Total:   page.Total.GetValue(),
+		Skip:    page.Skip.GetValue(),
+		Limit:   page.Limit.GetValue(),
+		HasNext: page.HasNext.GetValue(),
+	}
+}
+
+func FromPageInfoProto[T any](items []T, value *PageInfo) bsgostuff_domain.Page[T] {
+	if items == nil {
+		items = make([]T, 0)
+	}
+	if value == nil {
+		return bsgostuff_domain.Page[T]{Items: items}
+	}
+
+	return bsgostuff_domain.Page[T]{
+		Items:   items,
+		Total:   bsgostuff_types.NewInt(value.Total),
+		Skip:    bsgostuff_types.NewInt(value.Skip),
+		Limit:   bsgostuff_types.NewInt(value.Limit),
+		HasNext: bsgostuff_types.NewBool(value.HasNext),
+	}
+}
+
+// Connection - Relay connection для GraphQL. gqlgen не связывает обобщенные типы
+// напрямую, поэтому сервис объявляет псевдоним и указывает его в models:
+//
+//	type UserConnection = bsgostuff_proto.Connection[*User]
+//	type UserEdge = bsgostuff_proto.Edge[*User]
+//
+//	type UserConnection { edges: [UserEdge!]! pageInfo: PageInfo! totalCount: Int! }
+//	type UserEdge { cursor: String! node: User! }
+//	type PageInfo { hasNextPage: Boolean! hasPreviousPage: Boolean! startCursor: String endCursor: String }
+type Connection[T any] struct {
+	Edges      []*Edge[T]          `json:"edges"`
+	PageInfo   *ConnectionPageInfo `json:"pageInfo"`
+	TotalCount int64               `json:"totalCount"`
+}
+
+type Edge[T any] struct {
+	Cursor string `json:"cursor"`
+	Node   T      `json:"node"`
+}
+
+type ConnectionPageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
+
+// offsetCursorPrefix - курсор Relay поверх Skip/Limit кодирует смещение элемента
+const offsetCursorPrefix = "offset:"
+
+// ToConnection преобразует страницу в Relay connection; курсоры ребер - смещения элементов
+func ToConnection[T any](page bsgostuff_domain.Page[T]) *Connection[T] {
+	skip := page.Skip.GetValue()
+
+	edges := make([]*Edge[T], len(page.Items))
+	for i, item := range page.Items {
+		edges[i] = &Edge[T]{Cursor: OffsetCursor(skip + int64(i)), Node: item}
+	}
+
+	pageInfo := &ConnectionPageInfo{
+		HasNextPage:     page.HasNext.GetValue(),
+		HasPreviousPage: skip > 0,
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	return &Connection[T]{
+		Edges:      edges,
+		PageInfo:   pageInfo,
+		TotalCount: page.Total.GetValue(),
+	}
+}
+
+// FromConnectionArgs преобразует аргументы Relay first/after в Skip/Limit.
+// Без first Limit не задается: размер страницы по умолчанию и максимальный
+// определяет domain.PageSize на стороне хранилища (Repository.ListPage).
+func FromConnectionArgs(first *int, after *string) (bsgostuff_domain.Pagination, error) {
+	pagination := bsgostuff_domain.Pagination{Skip: bsgostuff_types.NewInt(0)}
+
+	if first != nil {
+		if *first < 0 {
+			return pagination, bsgostuff_domain.NewInvalidArgumentError(bsgostuff_domain.FieldViolation{
+				Field: "first", Code: bsgostuff_domain.ViolationMin, Message: "must be at least 0",
+			})
+		}
+		pagination.Limit = bsgostuff_types.NewInt(int64(*first))
+	}
+
+	if after != nil && *after != "" {
+		offset, err := ParseOffsetCursor(*after)
+		if err != nil {
+			return pagination, err
+		}
+		pagination.Skip = bsgostuff_types.NewInt(offset + 1)
+	}
+
+	return pagination, nil
+}
+
+// OffsetCursor кодирует смещение элемента в непрозрачный курсор
+func OffsetCursor(offset int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(offsetCursorPrefix + strconv.FormatInt(offset, 10)))
+}
+
+// ParseOffsetCursor декодирует курсор OffsetCursor
+func ParseOffsetCursor(cursor string) (int64, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		if value, ok := strings.CutPrefix(string(decoded), offsetCursorPrefix); ok {
+			if offset, err := strconv.ParseInt(value, 10, 64); err == nil && offset >= 0 {
+				return offset, nil
+			}
+		}
+	}
+
+	return 0, bsgostuff_domain.NewInvalidArgumentError(bsgostuff_domain.FieldViolation{
+		Field: "after", Code: bsgostuff_domain.ViolationInvalid, Message: "malformed cursor",
+	})
+}
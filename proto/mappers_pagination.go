@@ -23,9 +23,11 @@ func ToPaginationProto(value *bsgostuff_domain.Pagination) *Pagination {
 	}
 }
 
+// FromPaginationProto не подставляет limit по умолчанию:
+// размер страницы определяет domain.PageSize на стороне хранилища
 func FromPaginationProto(value *Pagination) bsgostuff_domain.Pagination {
 	_skip := bsgostuff_types.NewInt(0)
-	_limit := bsgostuff_types.NewInt(100)
+	_limit := bsgostuff_types.Int{}
 
 	if value != nil {
 		if value.Skip != nil {
@@ -45,3 +47,68 @@ func FromPaginationProto(value *Pagination) bsgostuff_domain.Pagination {
 func (value *Pagination) FromProto() bsgostuff_domain.Pagination {
 	return FromPaginationProto(value)
 }
+
+func ToCursorPaginationProto(value *bsgostuff_domain.CursorPagination) *CursorPagination {
+	_pagination := bsgostuff_types.DerefZero(value)
+
+	return &CursorPagination{
+		Cursor: _pagination.Cursor.GetPtr(),
+		Limit:  _pagination.Limit.GetPtr(),
+	}
+}
+
+// FromCursorPaginationProto не подставляет limit по умолчанию:
+// размер страницы определяет domain.PageSize на стороне хранилища
+func FromCursorPaginationProto(value *CursorPagination) bsgostuff_domain.CursorPagination {
+	if value == nil {
+		return bsgostuff_domain.CursorPagination{}
+	}
+
+	_cursor := bsgostuff_types.String{}
+	if value.Cursor != nil && *value.Cursor != "" {
+		_cursor.Set(*value.Cursor)
+	}
+
+	_limit := bsgostuff_types.Int{}
+	if value.Limit != nil && *value.Limit != 0 {
+		_limit.Set(*value.Limit)
+	}
+
+	return bsgostuff_domain.CursorPagination{
+		Cursor: _cursor,
+		Limit:  _limit,
+	}
+}
+
+func (value *CursorPagination) FromProto() bsgostuff_domain.CursorPagination {
+	return FromCursorPaginationProto(value)
+}
+
+func ToCursorPageInfoProto(value *bsgostuff_domain.CursorPageInfo) *CursorPageInfo {
+	_pageInfo := bsgostuff_types.DerefZero(value)
+
+	return &CursorPageInfo{
+		NextCursor: _pageInfo.NextCursor.GetPtr(),
+		HasMore:    _pageInfo.HasMore.GetValue(),
+	}
+}
+
+func FromCursorPageInfoProto(value *CursorPageInfo) bsgostuff_domain.CursorPageInfo {
+	if value == nil {
+		return bsgostuff_domain.CursorPageInfo{}
+	}
+
+	_nextCursor := bsgostuff_types.String{}
+	if value.NextCursor != nil {
+		_nextCursor.Set(*value.NextCursor)
+	}
+
+	return bsgostuff_domain.CursorPageInfo{
+		NextCursor: _nextCursor,
+		HasMore:    bsgostuff_types.NewBool(value.HasMore),
+	}
+}
+
+func (value *CursorPageInfo) FromProto() bsgostuff_domain.CursorPageInfo {
+	return FromCursorPageInfoProto(value)
+}
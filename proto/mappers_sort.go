@@ -8,9 +8,15 @@ import (
 func ToSortProto(value *bsgostuff_domain.Sort) *Sort {
 	_sort := bsgostuff_types.DerefZero(value)
 
+	var _nulls *NullsOrder
+	if _sort.Nulls.IsSet() && !_sort.Nulls.IsNull() {
+		_nulls = ToNullsOrderProto(_sort.Nulls.GetValue()).Enum()
+	}
+
 	return &Sort{
 		Field:   _sort.Field.GetPtr(),
 		Reverse: _sort.IsReversed.GetPtr(),
+		Nulls:   _nulls,
 	}
 }
 
@@ -29,12 +35,115 @@ func FromSortProto(value *Sort) bsgostuff_domain.Sort {
 		_isReversed.Set(*value.Reverse)
 	}
 
+	_nulls := bsgostuff_types.Enum[bsgostuff_domain.NullsOrderEnum]{}
+	if value.Nulls != nil {
+		_nulls.Set(FromNullsOrderProto(*value.Nulls))
+	}
+
 	return bsgostuff_domain.Sort{
 		Field:      _field,
 		IsReversed: _isReversed,
+		Nulls:      _nulls,
 	}
 }
 
 func (value *Sort) FromProto() bsgostuff_domain.Sort {
 	return FromSortProto(value)
 }
+
+func ToSortsProto(values []bsgostuff_domain.Sort) []*Sort {
+	result := make([]*Sort, len(values))
+	for i := range values {
+		result[i] = ToSortProto(&values[i])
+	}
+	return result
+}
+
+func FromSortsProto(values []*Sort) []bsgostuff_domain.Sort {
+	result := make([]bsgostuff_domain.Sort, 0, len(values))
+	for _, value := range values {
+		if value != nil {
+			result = append(result, FromSortProto(value))
+		}
+	}
+	return result
+}
+
+func ToNullsOrderProto(value bsgostuff_domain.NullsOrderEnum) NullsOrder {
+	switch value {
+	case bsgostuff_domain.NullsOrderEnumFirst:
+		return NullsOrder_NULLS_ORDER_FIRST
+	case bsgostuff_domain.NullsOrderEnumLast:
+		return NullsOrder_NULLS_ORDER_LAST
+	default:
+		return NullsOrder_NULLS_ORDER_UNKNOWN
+	}
+}
+
+func FromNullsOrderProto(value NullsOrder) bsgostuff_domain.NullsOrderEnum {
+	switch value {
+	case NullsOrder_NULLS_ORDER_FIRST:
+		return bsgostuff_domain.NullsOrderEnumFirst
+	case NullsOrder_NULLS_ORDER_LAST:
+		return bsgostuff_domain.NullsOrderEnumLast
+	default:
+		return bsgostuff_domain.NullsOrderEnumUnknown
+	}
+}
+
+// SortInput - входной тип сортировки GraphQL:
+//
+//	enum NullsOrder { FIRST LAST }
+//	input SortInput { field: String! reverse: Boolean nulls: NullsOrder }
+type SortInput struct {
+	Field   string  `json:"field"`
+	Reverse *bool   `json:"reverse"`
+	Nulls   *string `json:"nulls"`
+}
+
+func ToSortInput(value *bsgostuff_domain.Sort) *SortInput {
+	_sort := bsgostuff_types.DerefZero(value)
+
+	var _nulls *string
+	if nulls := _sort.Nulls.GetPtr(); nulls != nil && *nulls != bsgostuff_domain.NullsOrderEnumUnknown {
+		_nulls = bsgostuff_types.ToPtr(string(*nulls))
+	}
+
+	return &SortInput{
+		Field:   _sort.Field.GetValue(),
+		Reverse: _sort.IsReversed.GetPtr(),
+		Nulls:   _nulls,
+	}
+}
+
+func FromSortInput(value *SortInput) bsgostuff_domain.Sort {
+	if value == nil {
+		return bsgostuff_domain.Sort{}
+	}
+
+	_isReversed := bsgostuff_types.Bool{}
+	if value.Reverse != nil {
+		_isReversed.Set(*value.Reverse)
+	}
+
+	_nulls := bsgostuff_types.Enum[bsgostuff_domain.NullsOrderEnum]{}
+	if value.Nulls != nil {
+		_nulls.Set(bsgostuff_domain.NullsOrderEnum(*value.Nulls))
+	}
+
+	return bsgostuff_domain.Sort{
+		Field:      bsgostuff_types.NewString(value.Field),
+		IsReversed: _isReversed,
+		Nulls:      _nulls,
+	}
+}
+
+func FromSortInputs(values []*SortInput) []bsgostuff_domain.Sort {
+	result := make([]bsgostuff_domain.Sort, 0, len(values))
+	for _, value := range values {
+		if value != nil {
+			result = append(result, FromSortInput(value))
+		}
+	}
+	return result
+}
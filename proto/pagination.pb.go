@@ -73,6 +73,178 @@ func (x *Pagination) GetLimit() int64 {
 	return 0
 }
 
+type CursorPagination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        *string                `protobuf:"bytes,1,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	Limit         *int64                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CursorPagination) Reset() {
+	*x = CursorPagination{}
+	mi := &file_proto_definitions_pagination_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CursorPagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CursorPagination) ProtoMessage() {}
+
+func (x *CursorPagination) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_definitions_pagination_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CursorPagination.ProtoReflect.Descriptor instead.
+func (*CursorPagination) Descriptor() ([]byte, []int) {
+	return file_proto_definitions_pagination_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CursorPagination) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *CursorPagination) GetLimit() int64 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type CursorPageInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NextCursor    *string                `protobuf:"bytes,1,opt,name=next_cursor,json=nextCursor,proto3,oneof" json:"next_cursor,omitempty"`
+	HasMore       bool                   `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CursorPageInfo) Reset() {
+	*x = CursorPageInfo{}
+	mi := &file_proto_definitions_pagination_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CursorPageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CursorPageInfo) ProtoMessage() {}
+
+func (x *CursorPageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_definitions_pagination_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CursorPageInfo.ProtoReflect.Descriptor instead.
+func (*CursorPageInfo) Descriptor() ([]byte, []int) {
+	return file_proto_definitions_pagination_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CursorPageInfo) GetNextCursor() string {
+	if x != nil && x.NextCursor != nil {
+		return *x.NextCursor
+	}
+	return ""
+}
+
+func (x *CursorPageInfo) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type PageInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Skip          int64                  `protobuf:"varint,2,opt,name=skip,proto3" json:"skip,omitempty"`
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	HasNext       bool                   `protobuf:"varint,4,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageInfo) Reset() {
+	*x = PageInfo{}
+	mi := &file_proto_definitions_pagination_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageInfo) ProtoMessage() {}
+
+func (x *PageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_definitions_pagination_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
+func (*PageInfo) Descriptor() ([]byte, []int) {
+	return file_proto_definitions_pagination_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PageInfo) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PageInfo) GetSkip() int64 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+func (x *PageInfo) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *PageInfo) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
 var File_proto_definitions_pagination_proto protoreflect.FileDescriptor
 
 const file_proto_definitions_pagination_proto_rawDesc = "" +
@@ -83,7 +255,22 @@ const file_proto_definitions_pagination_proto_rawDesc = "" +
 	"\x04skip\x18\x01 \x01(\x03H\x00R\x04skip\x88\x01\x01\x12\x19\n" +
 	"\x05limit\x18\x02 \x01(\x03H\x01R\x05limit\x88\x01\x01B\a\n" +
 	"\x05_skipB\b\n" +
-	"\x06_limitB9Z7github.com/beavernsticks/go-stuff/proto;bsgostuff_protob\x06proto3"
+	"\x06_limit\"_\n" +
+	"\x10CursorPagination\x12\x1b\n" +
+	"\x06cursor\x18\x01 \x01(\tH\x00R\x06cursor\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x02 \x01(\x03H\x01R\x05limit\x88\x01\x01B\t\n" +
+	"\a_cursorB\b\n" +
+	"\x06_limit\"a\n" +
+	"\x0eCursorPageInfo\x12$\n" +
+	"\vnext_cursor\x18\x01 \x01(\tH\x00R\n" +
+	"nextCursor\x88\x01\x01\x12\x19\n" +
+	"\bhas_more\x18\x02 \x01(\bR\ahasMoreB\x0e\n" +
+	"\f_next_cursor\"e\n" +
+	"\bPageInfo\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04skip\x18\x02 \x01(\x03R\x04skip\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x19\n" +
+	"\bhas_next\x18\x04 \x01(\bR\ahasNextB9Z7github.com/beavernsticks/go-stuff/proto;bsgostuff_protob\x06proto3"
 
 var (
 	file_proto_definitions_pagination_proto_rawDescOnce sync.Once
@@ -97,9 +284,12 @@ func file_proto_definitions_pagination_proto_rawDescGZIP() []byte {
 	return file_proto_definitions_pagination_proto_rawDescData
 }
 
-var file_proto_definitions_pagination_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_proto_definitions_pagination_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_proto_definitions_pagination_proto_goTypes = []any{
-	(*Pagination)(nil), // 0: bsgostuff.Pagination
+	(*Pagination)(nil),       // 0: bsgostuff.Pagination
+	(*CursorPagination)(nil), // 1: bsgostuff.CursorPagination
+	(*CursorPageInfo)(nil),   // 2: bsgostuff.CursorPageInfo
+	(*PageInfo)(nil),         // 3: bsgostuff.PageInfo
 }
 var file_proto_definitions_pagination_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -115,13 +305,15 @@ func file_proto_definitions_pagination_proto_init() {
 		return
 	}
 	file_proto_definitions_pagination_proto_msgTypes[0].OneofWrappers = []any{}
+	file_proto_definitions_pagination_proto_msgTypes[1].OneofWrappers = []any{}
+	file_proto_definitions_pagination_proto_msgTypes[2].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_definitions_pagination_proto_rawDesc), len(file_proto_definitions_pagination_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
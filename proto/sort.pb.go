@@ -21,10 +21,60 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type NullsOrder int32
+
+const (
+	NullsOrder_NULLS_ORDER_UNKNOWN NullsOrder = 0
+	NullsOrder_NULLS_ORDER_FIRST   NullsOrder = 1
+	NullsOrder_NULLS_ORDER_LAST    NullsOrder = 2
+)
+
+// Enum value maps for NullsOrder.
+var (
+	NullsOrder_name = map[int32]string{
+		0: "NULLS_ORDER_UNKNOWN",
+		1: "NULLS_ORDER_FIRST",
+		2: "NULLS_ORDER_LAST",
+	}
+	NullsOrder_value = map[string]int32{
+		"NULLS_ORDER_UNKNOWN": 0,
+		"NULLS_ORDER_FIRST":   1,
+		"NULLS_ORDER_LAST":    2,
+	}
+)
+
+func (x NullsOrder) Enum() *NullsOrder {
+	p := new(NullsOrder)
+	*p = x
+	return p
+}
+
+func (x NullsOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NullsOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_definitions_sort_proto_enumTypes[0].Descriptor()
+}
+
+func (NullsOrder) Type() protoreflect.EnumType {
+	return &file_proto_definitions_sort_proto_enumTypes[0]
+}
+
+func (x NullsOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NullsOrder.Descriptor instead.
+func (NullsOrder) EnumDescriptor() ([]byte, []int) {
+	return file_proto_definitions_sort_proto_rawDescGZIP(), []int{0}
+}
+
 type Sort struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Field         *string                `protobuf:"bytes,1,opt,name=field,proto3,oneof" json:"field,omitempty"`
 	Reverse       *bool                  `protobuf:"varint,2,opt,name=reverse,proto3,oneof" json:"reverse,omitempty"`
+	Nulls         *NullsOrder            `protobuf:"varint,3,opt,name=nulls,proto3,enum=bsgostuff.NullsOrder,oneof" json:"nulls,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -73,17 +123,31 @@ func (x *Sort) GetReverse() bool {
 	return false
 }
 
+func (x *Sort) GetNulls() NullsOrder {
+	if x != nil && x.Nulls != nil {
+		return *x.Nulls
+	}
+	return NullsOrder_NULLS_ORDER_UNKNOWN
+}
+
 var File_proto_definitions_sort_proto protoreflect.FileDescriptor
 
 const file_proto_definitions_sort_proto_rawDesc = "" +
 	"\n" +
-	"\x1cproto/definitions/sort.proto\x12\tbsgostuff\"V\n" +
+	"\x1cproto/definitions/sort.proto\x12\tbsgostuff\"\x92\x01\n" +
 	"\x04Sort\x12\x19\n" +
 	"\x05field\x18\x01 \x01(\tH\x00R\x05field\x88\x01\x01\x12\x1d\n" +
-	"\areverse\x18\x02 \x01(\bH\x01R\areverse\x88\x01\x01B\b\n" +
+	"\areverse\x18\x02 \x01(\bH\x01R\areverse\x88\x01\x01\x120\n" +
+	"\x05nulls\x18\x03 \x01(\x0e2\x15.bsgostuff.NullsOrderH\x02R\x05nulls\x88\x01\x01B\b\n" +
 	"\x06_fieldB\n" +
 	"\n" +
-	"\b_reverseB9Z7github.com/beavernsticks/go-stuff/proto;bsgostuff_protob\x06proto3"
+	"\b_reverseB\b\n" +
+	"\x06_nulls*R\n" +
+	"\n" +
+	"NullsOrder\x12\x17\n" +
+	"\x13NULLS_ORDER_UNKNOWN\x10\x00\x12\x15\n" +
+	"\x11NULLS_ORDER_FIRST\x10\x01\x12\x14\n" +
+	"\x10NULLS_ORDER_LAST\x10\x02B9Z7github.com/beavernsticks/go-stuff/proto;bsgostuff_protob\x06proto3"
 
 var (
 	file_proto_definitions_sort_proto_rawDescOnce sync.Once
@@ -97,16 +161,19 @@ func file_proto_definitions_sort_proto_rawDescGZIP() []byte {
 	return file_proto_definitions_sort_proto_rawDescData
 }
 
+var file_proto_definitions_sort_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_proto_definitions_sort_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_proto_definitions_sort_proto_goTypes = []any{
-	(*Sort)(nil), // 0: bsgostuff.Sort
+	(NullsOrder)(0), // 0: bsgostuff.NullsOrder
+	(*Sort)(nil),    // 1: bsgostuff.Sort
 }
 var file_proto_definitions_sort_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: bsgostuff.Sort.nulls:type_name -> bsgostuff.NullsOrder
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_proto_definitions_sort_proto_init() }
@@ -120,13 +187,14 @@ func file_proto_definitions_sort_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_definitions_sort_proto_rawDesc), len(file_proto_definitions_sort_proto_rawDesc)),
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_proto_definitions_sort_proto_goTypes,
 		DependencyIndexes: file_proto_definitions_sort_proto_depIdxs,
+		EnumInfos:         file_proto_definitions_sort_proto_enumTypes,
 		MessageInfos:      file_proto_definitions_sort_proto_msgTypes,
 	}.Build()
 	File_proto_definitions_sort_proto = out.File
@@ -1,9 +1,13 @@
 package bsgostuff_types
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"io"
 	"strconv"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -27,8 +31,16 @@ func NewBools(items []Bool) Bools {
 	return Bools(Slice[Bool]{items: items, set: true})
 }
 
-// Compile-time interface check
-var _ Settable[bool, *wrapperspb.BoolValue, pgtype.Bool] = (*Bool)(nil)
+// Compile-time interface checks
+var (
+	_ Settable[bool, *wrapperspb.BoolValue, pgtype.Bool] = (*Bool)(nil)
+	_ pgtype.BoolScanner                                 = (*Bool)(nil)
+	_ pgtype.BoolValuer                                  = Bool{}
+	_ graphql.Marshaler                                  = Bool{}
+	_ graphql.Unmarshaler                                = (*Bool)(nil)
+	_ json.Marshaler                                     = Bool{}
+	_ json.Unmarshaler                                   = (*Bool)(nil)
+)
 
 // NewBool creates an initialized boolean value.
 // Returns concrete type for better method chaining.
@@ -111,6 +123,13 @@ func (b Bool) IsNull() bool {
 	return b.null
 }
 
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (b Bool) IsZero() bool {
+	return !b.set
+}
+
 // ToProto converts to protobuf BoolValue wrapper.
 // Returns nil when unset or null.
 func (b Bool) ToProto() *wrapperspb.BoolValue {
@@ -128,3 +147,80 @@ func (b Bool) ToPgx() pgtype.Bool {
 		Valid: b.set && !b.null,
 	}
 }
+
+// ScanBool implements pgtype.BoolScanner so pgx can scan directly into Bool.
+// SQL NULL is mapped to the explicit null state.
+func (b *Bool) ScanBool(v pgtype.Bool) error {
+	return b.Set(v)
+}
+
+// BoolValue implements pgtype.BoolValuer so Bool can be used as a query argument.
+// Unset and null values are encoded as SQL NULL.
+func (b Bool) BoolValue() (pgtype.Bool, error) {
+	return b.ToPgx(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// SQL NULL is mapped to the explicit null state.
+func (b *Bool) Scan(src any) error {
+	if v, ok := src.([]byte); ok {
+		return b.Set(string(v))
+	}
+	return b.Set(src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns nil (SQL NULL) when unset or null.
+func (b Bool) Value() (driver.Value, error) {
+	if !b.set || b.null {
+		return nil, nil
+	}
+	return b.value, nil
+}
+
+// MarshalGQL implements graphql.Marshaler for gqlgen.
+// Writes null when unset or null.
+func (b Bool) MarshalGQL(w io.Writer) {
+	if !b.set || b.null {
+		graphql.Null.MarshalGQL(w)
+		return
+	}
+	graphql.MarshalBoolean(b.value).MarshalGQL(w)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler for gqlgen.
+// gqlgen calls it only for arguments present in the request, so an absent
+// argument stays unset while an explicit null sets the null state.
+func (b *Bool) UnmarshalGQL(v any) error {
+	if v == nil {
+		return b.Set(nil)
+	}
+	parsed, err := graphql.UnmarshalBoolean(v)
+	if err != nil {
+		return err
+	}
+	return b.Set(parsed)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Returns null when unset or null.
+func (b Bool) MarshalJSON() ([]byte, error) {
+	if !b.set || b.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(b.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// A JSON null sets the explicit null state; a missing key never calls this
+// method and leaves the value unset (JSON Merge Patch semantics).
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return b.Set(nil)
+	}
+	var value bool
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid bool JSON: %w", err)
+	}
+	return b.Set(value)
+}
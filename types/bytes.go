@@ -0,0 +1,275 @@
+package bsgostuff_types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Bytes implements a nullable binary blob with three-state logic:
+// - Set (with concrete value, possibly empty)
+// - Explicitly set to null
+// - Unset (not initialized)
+//
+// Suitable for thumbnails, hashes and other binary data stored in bytea columns.
+// JSON and GraphQL representation is a standard base64 string.
+//
+// PostgreSQL representation is a plain []byte where nil means SQL NULL,
+// matching how pgx encodes bytea values.
+//
+// Implements Settable[[]byte, *wrapperspb.BytesValue, []byte]
+type Bytes struct {
+	value []byte
+	set   bool
+	null  bool
+}
+
+type BytesList = Slice[Bytes]
+
+func NewBytesList(items []Bytes) BytesList {
+	return BytesList(Slice[Bytes]{items: items, set: true})
+}
+
+// Compile-time interface checks
+var (
+	_ Settable[[]byte, *wrapperspb.BytesValue, []byte] = (*Bytes)(nil)
+	_ pgtype.BytesScanner                              = (*Bytes)(nil)
+	_ pgtype.BytesValuer                               = Bytes{}
+	_ graphql.Marshaler                                = Bytes{}
+	_ graphql.Unmarshaler                              = (*Bytes)(nil)
+	_ json.Marshaler                                   = Bytes{}
+	_ json.Unmarshaler                                 = (*Bytes)(nil)
+)
+
+// NewBytes creates an initialized binary value.
+// A nil slice is stored as empty (not null); use Set(nil) for explicit null.
+// Returns concrete type for method chaining.
+func NewBytes(value []byte) Bytes {
+	if value == nil {
+		value = []byte{}
+	}
+	return Bytes{value: value, set: true}
+}
+
+// NewBytesFromBase64 creates a Bytes value from standard base64 string.
+// Returns error if string is not valid base64.
+func NewBytesFromBase64(s string) (Bytes, error) {
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return Bytes{}, fmt.Errorf("invalid base64 string: %w", err)
+	}
+	return NewBytes(decoded), nil
+}
+
+// Set assigns a value from supported types:
+// - []byte: direct value (nil slice treated as null)
+// - *[]byte: nil pointer treated as null
+// - string: raw bytes of the string
+// - *wrapperspb.BytesValue: nil treated as null
+// - nil: explicit null
+func (b *Bytes) Set(value any) error {
+	b.set = true
+	b.null = false
+	b.value = nil
+
+	switch v := value.(type) {
+	case []byte:
+		b.null = v == nil
+		b.value = v
+	case *[]byte:
+		if v == nil || *v == nil {
+			b.null = true
+		} else {
+			b.value = *v
+		}
+	case string:
+		b.value = []byte(v)
+	case *wrapperspb.BytesValue:
+		if v == nil {
+			b.null = true
+		} else {
+			b.value = v.GetValue()
+			if b.value == nil {
+				b.value = []byte{}
+			}
+		}
+	case nil:
+		b.null = true
+	default:
+		return fmt.Errorf("unsupported type: %T", value)
+	}
+	return nil
+}
+
+// GetValue returns the underlying bytes.
+// Returns nil when unset or null.
+func (b Bytes) GetValue() []byte {
+	if !b.set || b.null {
+		return nil
+	}
+	return b.value
+}
+
+// GetPtr returns a pointer to the bytes.
+// Returns nil when unset or null.
+func (b Bytes) GetPtr() *[]byte {
+	if !b.set || b.null {
+		return nil
+	}
+	return &b.value
+}
+
+// IsSet indicates whether the value was explicitly set.
+// Returns false for uninitialized zero values.
+func (b Bytes) IsSet() bool {
+	return b.set
+}
+
+// IsNull indicates whether the value was explicitly set to null.
+// Returns false for unset values.
+func (b Bytes) IsNull() bool {
+	return b.null
+}
+
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (b Bytes) IsZero() bool {
+	return !b.set
+}
+
+// ToProto converts to protobuf BytesValue wrapper.
+// Returns nil when unset or null.
+func (b Bytes) ToProto() *wrapperspb.BytesValue {
+	if !b.set || b.null {
+		return nil
+	}
+	return wrapperspb.Bytes(b.value)
+}
+
+// ToPgx returns the bytes for PostgreSQL bytea integration.
+// Returns nil (SQL NULL) when unset or null, non-nil slice otherwise.
+func (b Bytes) ToPgx() []byte {
+	if !b.set || b.null {
+		return nil
+	}
+	if b.value == nil {
+		return []byte{}
+	}
+	return b.value
+}
+
+// Len returns the number of bytes.
+// Returns 0 when unset or null.
+func (b Bytes) Len() int {
+	if !b.set || b.null {
+		return 0
+	}
+	return len(b.value)
+}
+
+// Equal reports whether both values have the same state and content.
+func (b Bytes) Equal(other Bytes) bool {
+	return b.set == other.set && b.null == other.null && bytes.Equal(b.value, other.value)
+}
+
+// Base64 returns the bytes encoded as standard base64 string.
+// Returns empty string when unset or null.
+func (b Bytes) Base64() string {
+	if !b.set || b.null {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(b.value)
+}
+
+// ScanBytes implements pgtype.BytesScanner so pgx can scan bytea columns
+// directly into Bytes. SQL NULL is mapped to the explicit null state.
+func (b *Bytes) ScanBytes(v []byte) error {
+	// Driver memory is only valid until the next call, keep a copy
+	return b.Set(bytes.Clone(v))
+}
+
+// BytesValue implements pgtype.BytesValuer so Bytes can be used as a query argument.
+// Unset and null values are encoded as SQL NULL.
+func (b Bytes) BytesValue() ([]byte, error) {
+	return b.ToPgx(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// SQL NULL is mapped to the explicit null state.
+func (b *Bytes) Scan(src any) error {
+	if v, ok := src.([]byte); ok {
+		return b.ScanBytes(v)
+	}
+	return b.Set(src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns nil (SQL NULL) when unset or null.
+func (b Bytes) Value() (driver.Value, error) {
+	if !b.set || b.null {
+		return nil, nil
+	}
+	return b.ToPgx(), nil
+}
+
+// MarshalGQL implements graphql.Marshaler for gqlgen.
+// Writes base64 string or null when unset or null.
+func (b Bytes) MarshalGQL(w io.Writer) {
+	if !b.set || b.null {
+		graphql.Null.MarshalGQL(w)
+		return
+	}
+	graphql.MarshalString(b.Base64()).MarshalGQL(w)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler for gqlgen.
+// gqlgen calls it only for arguments present in the request, so an absent
+// argument stays unset while an explicit null sets the null state.
+// Accepts standard base64 strings.
+func (b *Bytes) UnmarshalGQL(v any) error {
+	if v == nil {
+		return b.Set(nil)
+	}
+	encoded, err := graphql.UnmarshalString(v)
+	if err != nil {
+		return err
+	}
+	parsed, err := NewBytesFromBase64(encoded)
+	if err != nil {
+		return err
+	}
+	*b = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Returns base64 string or null when unset or null.
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	if !b.set || b.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(b.Base64())
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// A JSON null sets the explicit null state; a missing key never calls this
+// method and leaves the value unset (JSON Merge Patch semantics).
+// Accepts standard base64 strings.
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return b.Set(nil)
+	}
+	var value []byte
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid bytes JSON: %w", err)
+	}
+	return b.Set(NewBytes(value).value)
+}
@@ -0,0 +1,293 @@
+package bsgostuff_types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/type/date"
+)
+
+// DateLayout is the canonical textual representation of Date (ISO 8601).
+const DateLayout = "2006-01-02"
+
+// Date implements a nullable calendar date (without time and zone) with three-state logic:
+// - Set (with concrete value)
+// - Explicitly set to null
+// - Unset (not initialized)
+//
+// Suitable for birthdays, due dates and other day-precision values.
+// The value is stored as time.Time at midnight UTC, so dates compare
+// and round-trip independently of the local time zone.
+//
+// Implements Settable[time.Time, *date.Date, pgtype.Date]
+type Date struct {
+	value time.Time
+	set   bool
+	null  bool
+}
+
+type Dates = Slice[Date]
+
+func NewDates(items []Date) Dates {
+	return Dates(Slice[Date]{items: items, set: true})
+}
+
+// Compile-time interface checks
+var (
+	_ Settable[time.Time, *date.Date, pgtype.Date] = (*Date)(nil)
+	_ pgtype.DateScanner                           = (*Date)(nil)
+	_ pgtype.DateValuer                            = Date{}
+	_ graphql.Marshaler                            = Date{}
+	_ graphql.Unmarshaler                          = (*Date)(nil)
+	_ json.Marshaler                               = Date{}
+	_ json.Unmarshaler                             = (*Date)(nil)
+)
+
+// NewDate creates an initialized date from year, month and day.
+// Out-of-range values are normalized like time.Date does.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{value: time.Date(year, month, day, 0, 0, 0, 0, time.UTC), set: true}
+}
+
+// NewDateFromTime creates an initialized date from the calendar day of t
+// in its own location.
+func NewDateFromTime(t time.Time) Date {
+	return NewDate(t.Date())
+}
+
+// NewCurrentDate creates a new Date with current local day.
+func NewCurrentDate() Date {
+	return NewDateFromTime(time.Now())
+}
+
+// Set assigns a value from supported types:
+// - time.Time: calendar day in the value's location
+// - *time.Time: nil pointer treated as null
+// - string: "2006-01-02" or RFC3339 (empty string treated as null)
+// - pgtype.Date: respects Valid flag, infinity is rejected
+// - *date.Date: google.type.Date, partial dates are rejected, nil treated as null
+// - nil: explicit null
+func (d *Date) Set(value any) error {
+	d.set = true
+	d.null = false
+	d.value = time.Time{}
+
+	switch v := value.(type) {
+	case time.Time:
+		d.value = truncateToDate(v)
+	case *time.Time:
+		if v == nil {
+			d.null = true
+		} else {
+			d.value = truncateToDate(*v)
+		}
+	case string:
+		if v == "" {
+			d.null = true
+			return nil
+		}
+		if parsed, err := time.Parse(DateLayout, v); err == nil {
+			d.value = parsed
+			return nil
+		}
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return fmt.Errorf("failed to parse date string (neither %s nor RFC3339): %w", DateLayout, err)
+		}
+		d.value = truncateToDate(parsed)
+	case pgtype.Date:
+		if !v.Valid {
+			d.null = true
+			return nil
+		}
+		if v.InfinityModifier != pgtype.Finite {
+			return fmt.Errorf("unsupported date value: infinity")
+		}
+		d.value = truncateToDate(v.Time)
+	case *date.Date:
+		if v == nil {
+			d.null = true
+			return nil
+		}
+		if v.GetYear() == 0 || v.GetMonth() == 0 || v.GetDay() == 0 {
+			return fmt.Errorf("partial google.type.Date is not supported: %d-%d-%d", v.GetYear(), v.GetMonth(), v.GetDay())
+		}
+		d.value = time.Date(int(v.GetYear()), time.Month(v.GetMonth()), int(v.GetDay()), 0, 0, 0, 0, time.UTC)
+	case nil:
+		d.null = true
+	default:
+		return fmt.Errorf("unsupported type: %T", value)
+	}
+	return nil
+}
+
+// truncateToDate drops the clock and zone keeping the calendar day
+func truncateToDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// GetValue returns the date as time.Time at midnight UTC.
+// Returns zero time when unset or null.
+func (d Date) GetValue() time.Time {
+	return d.value
+}
+
+// GetPtr returns a pointer to the time.Time value.
+// Returns nil when unset or null.
+func (d Date) GetPtr() *time.Time {
+	if !d.set || d.null {
+		return nil
+	}
+	return &d.value
+}
+
+// IsSet indicates whether the value was explicitly set.
+// Returns false for uninitialized zero values.
+func (d Date) IsSet() bool {
+	return d.set
+}
+
+// IsNull indicates whether the value was explicitly set to null.
+// Returns false for unset values.
+func (d Date) IsNull() bool {
+	return d.null
+}
+
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (d Date) IsZero() bool {
+	return !d.set
+}
+
+// ToProto converts to google.type.Date.
+// Returns nil when unset or null.
+func (d Date) ToProto() *date.Date {
+	if !d.set || d.null {
+		return nil
+	}
+	return &date.Date{
+		Year:  int32(d.value.Year()),
+		Month: int32(d.value.Month()),
+		Day:   int32(d.value.Day()),
+	}
+}
+
+// ToPgx converts to pgtype.Date for PostgreSQL integration.
+// Sets Valid flag according to null/unset state.
+func (d Date) ToPgx() pgtype.Date {
+	return pgtype.Date{
+		Time:  d.value,
+		Valid: d.set && !d.null,
+	}
+}
+
+// String returns the date in "2006-01-02" format.
+// Returns empty string when unset or null.
+func (d Date) String() string {
+	if !d.set || d.null {
+		return ""
+	}
+	return d.value.Format(DateLayout)
+}
+
+// In returns midnight of the date in the given location.
+// Returns zero time when unset or null.
+func (d Date) In(loc *time.Location) time.Time {
+	if !d.set || d.null {
+		return time.Time{}
+	}
+	return time.Date(d.value.Year(), d.value.Month(), d.value.Day(), 0, 0, 0, 0, loc)
+}
+
+// AddDays returns the date shifted by the given number of days.
+// Returns the value unchanged when unset or null.
+func (d Date) AddDays(days int) Date {
+	if !d.set || d.null {
+		return d
+	}
+	return Date{value: d.value.AddDate(0, 0, days), set: true}
+}
+
+// ScanDate implements pgtype.DateScanner so pgx can scan date columns
+// directly into Date. SQL NULL is mapped to the explicit null state.
+func (d *Date) ScanDate(v pgtype.Date) error {
+	return d.Set(v)
+}
+
+// DateValue implements pgtype.DateValuer so Date can be used as a query argument.
+// Unset and null values are encoded as SQL NULL.
+func (d Date) DateValue() (pgtype.Date, error) {
+	return d.ToPgx(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// SQL NULL is mapped to the explicit null state.
+func (d *Date) Scan(src any) error {
+	if v, ok := src.([]byte); ok {
+		return d.Set(string(v))
+	}
+	return d.Set(src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns "2006-01-02" string or nil (SQL NULL) when unset or null.
+func (d Date) Value() (driver.Value, error) {
+	if !d.set || d.null {
+		return nil, nil
+	}
+	return d.value.Format(DateLayout), nil
+}
+
+// MarshalGQL implements graphql.Marshaler for gqlgen.
+// Writes "2006-01-02" string or null when unset or null.
+func (d Date) MarshalGQL(w io.Writer) {
+	if !d.set || d.null {
+		graphql.Null.MarshalGQL(w)
+		return
+	}
+	graphql.MarshalString(d.value.Format(DateLayout)).MarshalGQL(w)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler for gqlgen.
+// gqlgen calls it only for arguments present in the request, so an absent
+// argument stays unset while an explicit null sets the null state.
+func (d *Date) UnmarshalGQL(v any) error {
+	if v == nil {
+		return d.Set(nil)
+	}
+	parsed, err := graphql.UnmarshalString(v)
+	if err != nil {
+		return err
+	}
+	return d.Set(parsed)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Returns "2006-01-02" string or null when unset or null.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if !d.set || d.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.value.Format(DateLayout))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// A JSON null sets the explicit null state; a missing key never calls this
+// method and leaves the value unset (JSON Merge Patch semantics).
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return d.Set(nil)
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid date JSON: %w", err)
+	}
+	return d.Set(value)
+}
@@ -0,0 +1,410 @@
+package bsgostuff_types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Decimal implements a nullable arbitrary-precision decimal with three-state logic:
+// - Set (with concrete value)
+// - Explicitly set to null
+// - Unset (not initialized)
+//
+// Stores the value as an unscaled big integer and a non-positive exponent
+// (value = coefficient * 10^exp), so the scale is preserved: "1.50" stays "1.50".
+// Intended for money and other values that must not lose precision.
+//
+// Native and proto representations use the canonical decimal string
+// (e.g. "-123.45") to avoid float rounding on the wire.
+//
+// Parsed values are limited to the PostgreSQL numeric range: up to 131072 digits
+// before the decimal point and up to 16383 after it.
+//
+// Implements Settable[string, *wrapperspb.StringValue, pgtype.Numeric]
+type Decimal struct {
+	coef *big.Int
+	exp  int32
+	set  bool
+	null bool
+}
+
+type Decimals = Slice[Decimal]
+
+func NewDecimals(items []Decimal) Decimals {
+	return Decimals(Slice[Decimal]{items: items, set: true})
+}
+
+// Compile-time interface checks
+var (
+	_ Settable[string, *wrapperspb.StringValue, pgtype.Numeric] = (*Decimal)(nil)
+	_ pgtype.NumericScanner                                     = (*Decimal)(nil)
+	_ pgtype.NumericValuer                                      = Decimal{}
+	_ graphql.Marshaler                                         = Decimal{}
+	_ graphql.Unmarshaler                                       = (*Decimal)(nil)
+	_ json.Marshaler                                            = Decimal{}
+	_ json.Unmarshaler                                          = (*Decimal)(nil)
+)
+
+var decimalPattern = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+// PostgreSQL numeric limits
+const (
+	maxDecimalDigits = 131072 // digits before the decimal point
+	maxDecimalScale  = 16383  // digits after the decimal point
+)
+
+// NewDecimal creates an initialized decimal equal to coefficient * 10^exp.
+// Example: NewDecimal(12345, -2) represents 123.45.
+func NewDecimal(coefficient int64, exp int32) Decimal {
+	coef, normalizedExp := normalizeDecimal(big.NewInt(coefficient), exp)
+	return Decimal{coef: coef, exp: normalizedExp, set: true}
+}
+
+// NewDecimalFromString creates a Decimal from its string representation.
+// Accepts plain ("123.45") and scientific ("1.2345e2") notation.
+// Returns error if string is not a valid decimal.
+func NewDecimalFromString(s string) (Decimal, error) {
+	d := Decimal{}
+	err := d.Set(s)
+	return d, err
+}
+
+// MustNewDecimalFromString creates a Decimal from its string representation.
+// Panics if string is not a valid decimal.
+func MustNewDecimalFromString(s string) Decimal {
+	d, err := NewDecimalFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Set assigns a value from supported types:
+// - string, json.Number: plain or scientific notation (empty string treated as null)
+// - *string: nil pointer treated as null
+// - int, int32, int64: exact integer value
+// - float32, float64: shortest representation that round-trips the float
+// - *big.Int: exact integer value, nil treated as null
+// - *wrapperspb.StringValue: nil treated as null
+// - pgtype.Numeric: respects Valid flag, NaN and infinity are rejected
+// - nil: explicit null
+func (d *Decimal) Set(value any) error {
+	d.set = true
+	d.null = false
+	d.coef = nil
+	d.exp = 0
+
+	switch v := value.(type) {
+	case string:
+		return d.setString(v)
+	case json.Number:
+		return d.setString(string(v))
+	case *string:
+		if v == nil {
+			d.null = true
+			return nil
+		}
+		return d.setString(*v)
+	case int:
+		d.coef = big.NewInt(int64(v))
+	case int32:
+		d.coef = big.NewInt(int64(v))
+	case int64:
+		d.coef = big.NewInt(v)
+	case float64:
+		return d.setString(strconv.FormatFloat(v, 'f', -1, 64))
+	case float32:
+		return d.setString(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case *big.Int:
+		if v == nil {
+			d.null = true
+		} else {
+			d.coef = new(big.Int).Set(v)
+		}
+	case *wrapperspb.StringValue:
+		if v == nil {
+			d.null = true
+			return nil
+		}
+		return d.setString(v.GetValue())
+	case pgtype.Numeric:
+		if !v.Valid {
+			d.null = true
+			return nil
+		}
+		if v.NaN || v.InfinityModifier != pgtype.Finite {
+			return fmt.Errorf("unsupported numeric value: NaN or infinity")
+		}
+		coef := new(big.Int)
+		if v.Int != nil {
+			coef.Set(v.Int)
+		}
+		if err := checkDecimalRange(coef, int64(v.Exp)); err != nil {
+			return err
+		}
+		d.coef, d.exp = normalizeDecimal(coef, v.Exp)
+	case nil:
+		d.null = true
+	default:
+		return fmt.Errorf("unsupported type: %T", value)
+	}
+	return nil
+}
+
+// setString parses decimal string into coefficient and exponent
+func (d *Decimal) setString(s string) error {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		d.null = true
+		return nil
+	}
+	if !decimalPattern.MatchString(s) {
+		return fmt.Errorf("invalid decimal format: %q", s)
+	}
+
+	mantissa, exponent, hasExp := strings.Cut(strings.ToLower(s), "e")
+	exp := int64(0)
+	if hasExp {
+		parsed, err := strconv.ParseInt(exponent, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid decimal exponent: %w", err)
+		}
+		exp = parsed
+	}
+
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	coef, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return fmt.Errorf("invalid decimal format: %q", s)
+	}
+
+	exp -= int64(len(fracPart))
+	if err := checkDecimalRange(coef, exp); err != nil {
+		return fmt.Errorf("%w: %q", err, s)
+	}
+
+	d.coef, d.exp = normalizeDecimal(coef, int32(exp))
+	return nil
+}
+
+// checkDecimalRange rejects values outside the PostgreSQL numeric range
+// before the exponent is expanded, so huge exponents cannot exhaust CPU or memory.
+func checkDecimalRange(coef *big.Int, exp int64) error {
+	if exp < -maxDecimalScale {
+		return fmt.Errorf("decimal scale %d exceeds %d", -exp, maxDecimalScale)
+	}
+	if coef.Sign() == 0 {
+		return nil
+	}
+	if digits := int64(len(new(big.Int).Abs(coef).String())) + exp; digits > maxDecimalDigits {
+		return fmt.Errorf("decimal has %d digits before the point, max %d", digits, maxDecimalDigits)
+	}
+	return nil
+}
+
+// normalizeDecimal folds positive exponents into the coefficient
+// so that exp is always <= 0 and equals the negated scale.
+func normalizeDecimal(coef *big.Int, exp int32) (*big.Int, int32) {
+	if exp <= 0 {
+		return coef, exp
+	}
+	if coef.Sign() == 0 {
+		return coef, 0
+	}
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+	return coef.Mul(coef, pow), 0
+}
+
+// GetValue returns the canonical decimal string (e.g. "-123.45").
+// Returns empty string when unset or null.
+func (d Decimal) GetValue() string {
+	if !d.set || d.null || d.coef == nil {
+		return ""
+	}
+
+	digits := new(big.Int).Abs(d.coef).String()
+	scale := int(-d.exp)
+	if scale > 0 {
+		if len(digits) <= scale {
+			digits = strings.Repeat("0", scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+	if d.coef.Sign() < 0 {
+		digits = "-" + digits
+	}
+	return digits
+}
+
+// GetPtr returns a pointer to the canonical decimal string.
+// Returns nil when unset or null.
+func (d Decimal) GetPtr() *string {
+	if !d.set || d.null {
+		return nil
+	}
+	value := d.GetValue()
+	return &value
+}
+
+// IsSet indicates whether the value was explicitly set.
+// Returns false for uninitialized zero values.
+func (d Decimal) IsSet() bool {
+	return d.set
+}
+
+// IsNull indicates whether the value was explicitly set to null.
+// Returns false for unset values.
+func (d Decimal) IsNull() bool {
+	return d.null
+}
+
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (d Decimal) IsZero() bool {
+	return !d.set
+}
+
+// ToProto converts to protobuf StringValue wrapper with canonical decimal string.
+// Returns nil when unset or null.
+func (d Decimal) ToProto() *wrapperspb.StringValue {
+	if !d.set || d.null {
+		return nil
+	}
+	return wrapperspb.String(d.GetValue())
+}
+
+// ToPgx converts to pgtype.Numeric for PostgreSQL integration.
+// Sets Valid flag according to null/unset state.
+func (d Decimal) ToPgx() pgtype.Numeric {
+	if !d.set || d.null || d.coef == nil {
+		return pgtype.Numeric{Valid: false}
+	}
+	return pgtype.Numeric{
+		Int:   new(big.Int).Set(d.coef),
+		Exp:   d.exp,
+		Valid: true,
+	}
+}
+
+// Scale returns the number of digits after the decimal point.
+// Returns 0 when unset or null.
+func (d Decimal) Scale() int32 {
+	if !d.set || d.null {
+		return 0
+	}
+	return -d.exp
+}
+
+// Rat returns the exact value as big.Rat.
+// Returns nil when unset or null.
+func (d Decimal) Rat() *big.Rat {
+	if !d.set || d.null || d.coef == nil {
+		return nil
+	}
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-d.exp)), nil)
+	return new(big.Rat).SetFrac(d.coef, denom)
+}
+
+// Float64 returns the nearest float64 value.
+// Returns 0 when unset or null.
+func (d Decimal) Float64() float64 {
+	r := d.Rat()
+	if r == nil {
+		return 0
+	}
+	f, _ := r.Float64()
+	return f
+}
+
+// String returns the canonical decimal string.
+// Returns empty string when unset or null.
+func (d Decimal) String() string {
+	return d.GetValue()
+}
+
+// ScanNumeric implements pgtype.NumericScanner so pgx can scan numeric
+// columns directly into Decimal. SQL NULL is mapped to the explicit null state.
+func (d *Decimal) ScanNumeric(v pgtype.Numeric) error {
+	return d.Set(v)
+}
+
+// NumericValue implements pgtype.NumericValuer so Decimal can be used as a query argument.
+// Unset and null values are encoded as SQL NULL.
+func (d Decimal) NumericValue() (pgtype.Numeric, error) {
+	return d.ToPgx(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// SQL NULL is mapped to the explicit null state.
+func (d *Decimal) Scan(src any) error {
+	if v, ok := src.([]byte); ok {
+		return d.Set(string(v))
+	}
+	return d.Set(src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns canonical decimal string or nil (SQL NULL) when unset or null.
+func (d Decimal) Value() (driver.Value, error) {
+	if !d.set || d.null {
+		return nil, nil
+	}
+	return d.GetValue(), nil
+}
+
+// MarshalGQL implements graphql.Marshaler for gqlgen.
+// Writes canonical decimal string or null when unset or null.
+func (d Decimal) MarshalGQL(w io.Writer) {
+	if !d.set || d.null {
+		graphql.Null.MarshalGQL(w)
+		return
+	}
+	graphql.MarshalString(d.GetValue()).MarshalGQL(w)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler for gqlgen.
+// gqlgen calls it only for arguments present in the request, so an absent
+// argument stays unset while an explicit null sets the null state.
+// Accepts strings and numbers.
+func (d *Decimal) UnmarshalGQL(v any) error {
+	return d.Set(v)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Encodes as JSON string to preserve precision; returns null when unset or null.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if !d.set || d.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.GetValue())
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// A JSON null sets the explicit null state; a missing key never calls this
+// method and leaves the value unset (JSON Merge Patch semantics).
+// Accepts both JSON strings and numbers without float rounding.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return d.Set(nil)
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		return d.Set(str)
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return fmt.Errorf("invalid decimal JSON: %w", err)
+	}
+	return d.Set(number)
+}
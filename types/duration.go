@@ -0,0 +1,276 @@
+package bsgostuff_types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Duration implements a nullable time.Duration with three-state logic:
+// - Set (with concrete value)
+// - Explicitly set to null
+// - Unset (not initialized)
+//
+// Suitable for TTLs, timeouts and other elapsed-time values.
+// PostgreSQL intervals with days and months are converted using
+// 24 hours per day and 30 days per month (same as EXTRACT(EPOCH FROM interval)).
+//
+// Implements Settable[time.Duration, *durationpb.Duration, pgtype.Interval]
+type Duration struct {
+	value time.Duration
+	set   bool
+	null  bool
+}
+
+type Durations = Slice[Duration]
+
+func NewDurations(items []Duration) Durations {
+	return Durations(Slice[Duration]{items: items, set: true})
+}
+
+// Compile-time interface checks
+var (
+	_ Settable[time.Duration, *durationpb.Duration, pgtype.Interval] = (*Duration)(nil)
+	_ pgtype.IntervalScanner                                         = (*Duration)(nil)
+	_ pgtype.IntervalValuer                                          = Duration{}
+	_ graphql.Marshaler                                              = Duration{}
+	_ graphql.Unmarshaler                                            = (*Duration)(nil)
+	_ json.Marshaler                                                 = Duration{}
+	_ json.Unmarshaler                                               = (*Duration)(nil)
+)
+
+const (
+	intervalDay   = 24 * time.Hour
+	intervalMonth = 30 * intervalDay
+)
+
+// NewDuration creates an initialized duration value.
+// Returns concrete type for method chaining.
+func NewDuration(value time.Duration) Duration {
+	return Duration{value: value, set: true}
+}
+
+// Set assigns a value from supported types:
+// - time.Duration: direct value
+// - *time.Duration: nil pointer treated as null
+// - string: Go duration format, e.g. "1h30m" (empty string treated as null)
+// - int64: treated as seconds
+// - pgtype.Interval: respects Valid flag
+// - *durationpb.Duration: nil treated as null
+// - nil: explicit null
+func (d *Duration) Set(value any) error {
+	d.set = true
+	d.null = false
+	d.value = 0
+
+	switch v := value.(type) {
+	case time.Duration:
+		d.value = v
+	case *time.Duration:
+		if v == nil {
+			d.null = true
+		} else {
+			d.value = *v
+		}
+	case string:
+		if v == "" {
+			d.null = true
+			return nil
+		}
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("failed to parse duration string: %w", err)
+		}
+		d.value = parsed
+	case int64:
+		d.value = time.Duration(v) * time.Second
+	case pgtype.Interval:
+		d.null = !v.Valid
+		d.value = time.Duration(v.Microseconds)*time.Microsecond +
+			time.Duration(v.Days)*intervalDay +
+			time.Duration(v.Months)*intervalMonth
+	case *durationpb.Duration:
+		if v == nil {
+			d.null = true
+			return nil
+		}
+		if err := v.CheckValid(); err != nil {
+			return fmt.Errorf("invalid protobuf duration: %w", err)
+		}
+		d.value = v.AsDuration()
+	case nil:
+		d.null = true
+	default:
+		return fmt.Errorf("unsupported type: %T", value)
+	}
+	return nil
+}
+
+// GetValue returns the underlying time.Duration value.
+// Returns 0 when unset or null.
+func (d Duration) GetValue() time.Duration {
+	return d.value
+}
+
+// GetPtr returns a pointer to the time.Duration value.
+// Returns nil when unset or null.
+func (d Duration) GetPtr() *time.Duration {
+	if !d.set || d.null {
+		return nil
+	}
+	return &d.value
+}
+
+// IsSet indicates whether the value was explicitly set.
+// Returns false for uninitialized zero values.
+func (d Duration) IsSet() bool {
+	return d.set
+}
+
+// IsNull indicates whether the value was explicitly set to null.
+// Returns false for unset values.
+func (d Duration) IsNull() bool {
+	return d.null
+}
+
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (d Duration) IsZero() bool {
+	return !d.set
+}
+
+// ToProto converts to protobuf Duration.
+// Returns nil when unset or null.
+func (d Duration) ToProto() *durationpb.Duration {
+	if !d.set || d.null {
+		return nil
+	}
+	return durationpb.New(d.value)
+}
+
+// ToPgx converts to pgtype.Interval for PostgreSQL integration.
+// The whole duration is stored in microseconds without days/months parts.
+// Sets Valid flag according to null/unset state.
+func (d Duration) ToPgx() pgtype.Interval {
+	return pgtype.Interval{
+		Microseconds: d.value.Microseconds(),
+		Valid:        d.set && !d.null,
+	}
+}
+
+// String returns the duration in Go format (e.g. "1h30m0s").
+// Returns empty string when unset or null.
+func (d Duration) String() string {
+	if !d.set || d.null {
+		return ""
+	}
+	return d.value.String()
+}
+
+// ScanInterval implements pgtype.IntervalScanner so pgx can scan interval
+// columns directly into Duration. SQL NULL is mapped to the explicit null state.
+func (d *Duration) ScanInterval(v pgtype.Interval) error {
+	return d.Set(v)
+}
+
+// IntervalValue implements pgtype.IntervalValuer so Duration can be used as a query argument.
+// Unset and null values are encoded as SQL NULL.
+func (d Duration) IntervalValue() (pgtype.Interval, error) {
+	return d.ToPgx(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// Accepts PostgreSQL interval text and Go duration strings.
+// SQL NULL is mapped to the explicit null state.
+func (d *Duration) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return d.Set(src)
+	}
+
+	if parsed, err := time.ParseDuration(text); err == nil {
+		return d.Set(parsed)
+	}
+
+	var interval pgtype.Interval
+	if err := interval.Scan(text); err != nil {
+		return fmt.Errorf("failed to parse interval: %w", err)
+	}
+	return d.Set(interval)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns PostgreSQL interval text or nil (SQL NULL) when unset or null.
+func (d Duration) Value() (driver.Value, error) {
+	if !d.set || d.null {
+		return nil, nil
+	}
+	return d.ToPgx().Value()
+}
+
+// MarshalGQL implements graphql.Marshaler for gqlgen.
+// Writes Go duration string or null when unset or null.
+func (d Duration) MarshalGQL(w io.Writer) {
+	if !d.set || d.null {
+		graphql.Null.MarshalGQL(w)
+		return
+	}
+	graphql.MarshalString(d.value.String()).MarshalGQL(w)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler for gqlgen.
+// gqlgen calls it only for arguments present in the request, so an absent
+// argument stays unset while an explicit null sets the null state.
+// Accepts Go duration strings and numbers of seconds.
+func (d *Duration) UnmarshalGQL(v any) error {
+	switch v := v.(type) {
+	case nil, string:
+		return d.Set(v)
+	default:
+		seconds, err := graphql.UnmarshalInt64(v)
+		if err != nil {
+			return fmt.Errorf("duration should be Go duration string or seconds: %w", err)
+		}
+		return d.Set(seconds)
+	}
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Returns Go duration string or null when unset or null.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	if !d.set || d.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.value.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// A JSON null sets the explicit null state; a missing key never calls this
+// method and leaves the value unset (JSON Merge Patch semantics).
+// Accepts Go duration strings and numbers of seconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return d.Set(nil)
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		return d.Set(str)
+	}
+	var seconds int64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return fmt.Errorf("duration should be Go duration string or seconds: %w", err)
+	}
+	return d.Set(seconds)
+}
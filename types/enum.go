@@ -1,11 +1,15 @@
 package bsgostuff_types
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"io"
 	"reflect"
 	"strconv"
 	"strings"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
@@ -41,8 +45,16 @@ func NewEnums[T comparable](items []Enum[T]) Enums[T] {
 	return Enums[T](Slice[Enum[T]]{items: items, set: true})
 }
 
-// Compile-time interface check
-var _ Settable[any, any, pgtype.Text] = (*Enum[any])(nil)
+// Compile-time interface checks
+var (
+	_ Settable[any, any, pgtype.Text] = (*Enum[any])(nil)
+	_ pgtype.TextScanner              = (*Enum[any])(nil)
+	_ pgtype.TextValuer               = Enum[any]{}
+	_ graphql.Marshaler               = Enum[any]{}
+	_ graphql.Unmarshaler             = (*Enum[any])(nil)
+	_ json.Marshaler                  = Enum[any]{}
+	_ json.Unmarshaler                = (*Enum[any])(nil)
+)
 
 // NewEnum creates an initialized enum value.
 // Returns concrete type for method chaining.
@@ -122,6 +134,13 @@ func (e Enum[T]) IsNull() bool {
 	return e.null
 }
 
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (e Enum[T]) IsZero() bool {
+	return !e.set
+}
+
 // ToProto converts to protobuf representation.
 // For protobuf enums, returns the enum value directly.
 // Returns zero value when unset or null.
@@ -146,6 +165,84 @@ func (e Enum[T]) ToPgx() pgtype.Text {
 	}
 }
 
+// ScanText implements pgtype.TextScanner so pgx can scan text and PostgreSQL
+// enum columns directly into Enum. SQL NULL is mapped to the explicit null state.
+func (e *Enum[T]) ScanText(v pgtype.Text) error {
+	return e.Set(v)
+}
+
+// TextValue implements pgtype.TextValuer so Enum can be used as a query argument.
+// Unset and null values are encoded as SQL NULL.
+func (e Enum[T]) TextValue() (pgtype.Text, error) {
+	return e.ToPgx(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// SQL NULL is mapped to the explicit null state.
+func (e *Enum[T]) Scan(src any) error {
+	if v, ok := src.([]byte); ok {
+		return e.Set(string(v))
+	}
+	return e.Set(src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns the string representation or nil (SQL NULL) when unset or null.
+func (e Enum[T]) Value() (driver.Value, error) {
+	if !e.set || e.null {
+		return nil, nil
+	}
+	return fmt.Sprint(e.value), nil
+}
+
+// MarshalGQL implements graphql.Marshaler for gqlgen.
+// Writes the enum name or null when unset or null.
+func (e Enum[T]) MarshalGQL(w io.Writer) {
+	if !e.set || e.null {
+		graphql.Null.MarshalGQL(w)
+		return
+	}
+	graphql.MarshalString(fmt.Sprint(e.value)).MarshalGQL(w)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler for gqlgen.
+// gqlgen calls it only for arguments present in the request, so an absent
+// argument stays unset while an explicit null sets the null state.
+func (e *Enum[T]) UnmarshalGQL(v any) error {
+	if v == nil {
+		return e.Set(nil)
+	}
+	parsed, err := graphql.UnmarshalString(v)
+	if err != nil {
+		return err
+	}
+	return e.Set(parsed)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Returns the enum name as string or null when unset or null.
+func (e Enum[T]) MarshalJSON() ([]byte, error) {
+	if !e.set || e.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(fmt.Sprint(e.value))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// A JSON null sets the explicit null state; a missing key never calls this
+// method and leaves the value unset (JSON Merge Patch semantics).
+// Accepts enum names as strings and numeric values for int-based enums.
+func (e *Enum[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return e.Set(nil)
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		str = string(data)
+	}
+	return e.Set(str)
+}
+
 // parseEnumFromString converts a string to enum value of type T.
 // Supports:
 // - Protobuf enums (via protoreflect)
@@ -164,7 +261,7 @@ func parseEnumFromString[T comparable](s string) (T, error) {
 		for i := 0; i < enumDesc.Values().Len(); i++ {
 			val := enumDesc.Values().Get(i)
 			if strings.EqualFold(string(val.Name()), strings.ToUpper(s)) {
-				return reflect.ValueOf(val.Number()).Interface().(T), nil
+				return reflect.ValueOf(val.Number()).Convert(tType).Interface().(T), nil
 			}
 		}
 		return zero, fmt.Errorf("unknown proto enum value: %s", s)
@@ -174,7 +271,7 @@ func parseEnumFromString[T comparable](s string) (T, error) {
 	switch tType.Kind() {
 	case reflect.String:
 		// For string-based enums (type Status string)
-		return any(strings.ToUpper(s)).(T), nil
+		return reflect.ValueOf(strings.ToUpper(s)).Convert(tType).Interface().(T), nil
 
 	case reflect.Int, reflect.Int32, reflect.Int64:
 		// For iota-based enums (type Status int)
@@ -0,0 +1,250 @@
+package bsgostuff_types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Float implements a nullable float64 with three-state logic:
+// - Set (with concrete value)
+// - Explicitly set to null
+// - Unset (not initialized)
+//
+// Intended for measurements and other approximate values.
+// Use Decimal for money and values requiring exact precision.
+//
+// Implements Settable[float64, *wrapperspb.DoubleValue, pgtype.Float8]
+type Float struct {
+	value float64
+	set   bool
+	null  bool
+}
+
+type Floats = Slice[Float]
+
+func NewFloats(items []Float) Floats {
+	return Floats(Slice[Float]{items: items, set: true})
+}
+
+// Compile-time interface checks
+var (
+	_ Settable[float64, *wrapperspb.DoubleValue, pgtype.Float8] = (*Float)(nil)
+	_ pgtype.Float64Scanner                                     = (*Float)(nil)
+	_ pgtype.Float64Valuer                                      = Float{}
+	_ graphql.Marshaler                                         = Float{}
+	_ graphql.Unmarshaler                                       = (*Float)(nil)
+	_ json.Marshaler                                            = Float{}
+	_ json.Unmarshaler                                          = (*Float)(nil)
+)
+
+// NewFloat creates an initialized float64 value.
+// Returns concrete type for method chaining.
+func NewFloat(value float64) Float {
+	return Float{value: value, set: true}
+}
+
+// Set assigns a value from supported types:
+// - float32, float64: converted to float64
+// - int, int32, int64: converted to float64
+// - *float32, *float64: nil pointer treated as null
+// - string: parsed as float64 (empty string treated as zero)
+// - *wrapperspb.DoubleValue: nil treated as null
+// - pgtype.Float8, pgtype.Float4: respects Valid flag
+// - nil: explicit null
+func (f *Float) Set(value any) error {
+	f.set = true
+	f.null = false
+	f.value = 0
+
+	switch v := value.(type) {
+	case float64:
+		f.value = v
+	case float32:
+		f.value = float64(v)
+	case int:
+		f.value = float64(v)
+	case int32:
+		f.value = float64(v)
+	case int64:
+		f.value = float64(v)
+	case *float64:
+		if v == nil {
+			f.null = true
+		} else {
+			f.value = *v
+		}
+	case *float32:
+		if v == nil {
+			f.null = true
+		} else {
+			f.value = float64(*v)
+		}
+	case string:
+		if v == "" {
+			return nil // treat empty string as zero value
+		}
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse string as float64: %w", err)
+		}
+		f.value = parsed
+	case *wrapperspb.DoubleValue:
+		if v == nil {
+			f.null = true
+		} else {
+			f.value = v.GetValue()
+		}
+	case pgtype.Float8:
+		f.null = !v.Valid
+		f.value = v.Float64
+	case pgtype.Float4:
+		f.null = !v.Valid
+		f.value = float64(v.Float32)
+	case nil:
+		f.null = true
+	default:
+		return fmt.Errorf("unsupported type: %T", value)
+	}
+	return nil
+}
+
+// GetValue returns the underlying float64 value.
+// Returns 0 when unset or null.
+func (f Float) GetValue() float64 {
+	return f.value
+}
+
+// GetPtr returns a pointer to the float64 value.
+// Returns nil when unset or null.
+func (f Float) GetPtr() *float64 {
+	if !f.set || f.null {
+		return nil
+	}
+	return &f.value
+}
+
+// IsSet indicates whether the value was explicitly set.
+// Returns false for uninitialized zero values.
+func (f Float) IsSet() bool {
+	return f.set
+}
+
+// IsNull indicates whether the value was explicitly set to null.
+// Returns false for unset values.
+func (f Float) IsNull() bool {
+	return f.null
+}
+
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (f Float) IsZero() bool {
+	return !f.set
+}
+
+// ToProto converts to protobuf DoubleValue wrapper.
+// Returns nil when unset or null.
+func (f Float) ToProto() *wrapperspb.DoubleValue {
+	if !f.set || f.null {
+		return nil
+	}
+	return wrapperspb.Double(f.value)
+}
+
+// ToPgx converts to pgtype.Float8 for PostgreSQL integration.
+// Sets Valid flag according to null/unset state.
+func (f Float) ToPgx() pgtype.Float8 {
+	return pgtype.Float8{
+		Float64: f.value,
+		Valid:   f.set && !f.null,
+	}
+}
+
+// ScanFloat64 implements pgtype.Float64Scanner so pgx can scan float4/float8
+// columns directly into Float. SQL NULL is mapped to the explicit null state.
+func (f *Float) ScanFloat64(v pgtype.Float8) error {
+	return f.Set(v)
+}
+
+// Float64Value implements pgtype.Float64Valuer so Float can be used as a query argument.
+// Unset and null values are encoded as SQL NULL.
+func (f Float) Float64Value() (pgtype.Float8, error) {
+	return f.ToPgx(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// SQL NULL is mapped to the explicit null state.
+func (f *Float) Scan(src any) error {
+	if v, ok := src.([]byte); ok {
+		return f.Set(string(v))
+	}
+	return f.Set(src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns nil (SQL NULL) when unset or null.
+func (f Float) Value() (driver.Value, error) {
+	if !f.set || f.null {
+		return nil, nil
+	}
+	return f.value, nil
+}
+
+// MarshalGQL implements graphql.Marshaler for gqlgen.
+// Writes null when unset or null.
+func (f Float) MarshalGQL(w io.Writer) {
+	if !f.set || f.null {
+		graphql.Null.MarshalGQL(w)
+		return
+	}
+	graphql.MarshalFloat(f.value).MarshalGQL(w)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler for gqlgen.
+// gqlgen calls it only for arguments present in the request, so an absent
+// argument stays unset while an explicit null sets the null state.
+func (f *Float) UnmarshalGQL(v any) error {
+	if v == nil {
+		return f.Set(nil)
+	}
+	parsed, err := graphql.UnmarshalFloat(v)
+	if err != nil {
+		return err
+	}
+	return f.Set(parsed)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Returns null when unset or null.
+func (f Float) MarshalJSON() ([]byte, error) {
+	if !f.set || f.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(f.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// A JSON null sets the explicit null state; a missing key never calls this
+// method and leaves the value unset (JSON Merge Patch semantics).
+// Accepts both JSON numbers and numeric strings.
+func (f *Float) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return f.Set(nil)
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		return f.Set(str)
+	}
+	var value float64
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid float JSON: %w", err)
+	}
+	return f.Set(value)
+}
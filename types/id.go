@@ -1,8 +1,12 @@
 package bsgostuff_types
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"io"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -26,8 +30,16 @@ func NewIDs(items []ID) IDs {
 	return IDs(Slice[ID]{items: items, set: true})
 }
 
-// Compile-time interface check
-var _ Settable[uuid.UUID, *wrapperspb.StringValue, pgtype.UUID] = (*ID)(nil)
+// Compile-time interface checks
+var (
+	_ Settable[uuid.UUID, *wrapperspb.StringValue, pgtype.UUID] = (*ID)(nil)
+	_ pgtype.UUIDScanner                                        = (*ID)(nil)
+	_ pgtype.UUIDValuer                                         = ID{}
+	_ graphql.Marshaler                                         = ID{}
+	_ graphql.Unmarshaler                                       = (*ID)(nil)
+	_ json.Marshaler                                            = ID{}
+	_ json.Unmarshaler                                          = (*ID)(nil)
+)
 
 // NewID creates a new initialized UUID value.
 // Generates random UUID using google/uuid package.
@@ -114,6 +126,13 @@ func (i ID) IsNull() bool {
 	return i.null
 }
 
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (i ID) IsZero() bool {
+	return !i.set
+}
+
 // ToProto converts to protobuf StringValue wrapper.
 // Returns nil when unset or null.
 // UUID is transmitted as canonical string representation.
@@ -141,3 +160,88 @@ func (i ID) String() string {
 	}
 	return i.value.String()
 }
+
+// ScanUUID implements pgtype.UUIDScanner so pgx can scan uuid columns
+// directly into ID. SQL NULL is mapped to the explicit null state.
+func (i *ID) ScanUUID(v pgtype.UUID) error {
+	return i.Set(v)
+}
+
+// UUIDValue implements pgtype.UUIDValuer so ID can be used as a query argument.
+// Unset and null values are encoded as SQL NULL.
+func (i ID) UUIDValue() (pgtype.UUID, error) {
+	return i.ToPgx(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// Accepts canonical UUID strings and raw 16-byte values.
+// SQL NULL is mapped to the explicit null state.
+func (i *ID) Scan(src any) error {
+	if v, ok := src.([]byte); ok {
+		if len(v) == 16 {
+			parsed, err := uuid.FromBytes(v)
+			if err != nil {
+				return fmt.Errorf("invalid UUID bytes: %w", err)
+			}
+			return i.Set(parsed)
+		}
+		return i.Set(string(v))
+	}
+	return i.Set(src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns canonical UUID string or nil (SQL NULL) when unset or null.
+func (i ID) Value() (driver.Value, error) {
+	if !i.set || i.null {
+		return nil, nil
+	}
+	return i.value.String(), nil
+}
+
+// MarshalGQL implements graphql.Marshaler for gqlgen.
+// Writes canonical UUID string or null when unset or null.
+func (i ID) MarshalGQL(w io.Writer) {
+	if !i.set || i.null {
+		graphql.Null.MarshalGQL(w)
+		return
+	}
+	graphql.MarshalID(i.value.String()).MarshalGQL(w)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler for gqlgen.
+// gqlgen calls it only for arguments present in the request, so an absent
+// argument stays unset while an explicit null sets the null state.
+func (i *ID) UnmarshalGQL(v any) error {
+	if v == nil {
+		return i.Set(nil)
+	}
+	parsed, err := graphql.UnmarshalID(v)
+	if err != nil {
+		return err
+	}
+	return i.Set(parsed)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Returns canonical UUID string or null when unset or null.
+func (i ID) MarshalJSON() ([]byte, error) {
+	if !i.set || i.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(i.value.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// A JSON null sets the explicit null state; a missing key never calls this
+// method and leaves the value unset (JSON Merge Patch semantics).
+func (i *ID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return i.Set(nil)
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid UUID JSON: %w", err)
+	}
+	return i.Set(value)
+}
@@ -1,9 +1,13 @@
 package bsgostuff_types
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"io"
 	"strconv"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -29,8 +33,16 @@ func NewInts(items []Int) Ints {
 	return Ints(Slice[Int]{items: items, set: true})
 }
 
-// Compile-time interface check
-var _ Settable[int64, *wrapperspb.Int64Value, pgtype.Int8] = (*Int)(nil)
+// Compile-time interface checks
+var (
+	_ Settable[int64, *wrapperspb.Int64Value, pgtype.Int8] = (*Int)(nil)
+	_ pgtype.Int64Scanner                                  = (*Int)(nil)
+	_ pgtype.Int64Valuer                                   = Int{}
+	_ graphql.Marshaler                                    = Int{}
+	_ graphql.Unmarshaler                                  = (*Int)(nil)
+	_ json.Marshaler                                       = Int{}
+	_ json.Unmarshaler                                     = (*Int)(nil)
+)
 
 // NewInt creates an initialized int64 value.
 // Returns concrete type for method chaining.
@@ -125,6 +137,13 @@ func (i Int) IsNull() bool {
 	return i.null
 }
 
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (i Int) IsZero() bool {
+	return !i.set
+}
+
 // ToProto converts to protobuf Int64Value wrapper.
 // Returns nil when unset or null.
 func (i Int) ToProto() *wrapperspb.Int64Value {
@@ -142,3 +161,85 @@ func (i Int) ToPgx() pgtype.Int8 {
 		Valid: i.set && !i.null,
 	}
 }
+
+// ScanInt64 implements pgtype.Int64Scanner so pgx can scan int2/int4/int8
+// columns directly into Int. SQL NULL is mapped to the explicit null state.
+func (i *Int) ScanInt64(v pgtype.Int8) error {
+	return i.Set(v)
+}
+
+// Int64Value implements pgtype.Int64Valuer so Int can be used as a query argument.
+// Unset and null values are encoded as SQL NULL.
+func (i Int) Int64Value() (pgtype.Int8, error) {
+	return i.ToPgx(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// SQL NULL is mapped to the explicit null state.
+func (i *Int) Scan(src any) error {
+	if v, ok := src.([]byte); ok {
+		return i.Set(string(v))
+	}
+	return i.Set(src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns nil (SQL NULL) when unset or null.
+func (i Int) Value() (driver.Value, error) {
+	if !i.set || i.null {
+		return nil, nil
+	}
+	return i.value, nil
+}
+
+// MarshalGQL implements graphql.Marshaler for gqlgen.
+// Writes null when unset or null.
+func (i Int) MarshalGQL(w io.Writer) {
+	if !i.set || i.null {
+		graphql.Null.MarshalGQL(w)
+		return
+	}
+	graphql.MarshalInt64(i.value).MarshalGQL(w)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler for gqlgen.
+// gqlgen calls it only for arguments present in the request, so an absent
+// argument stays unset while an explicit null sets the null state.
+func (i *Int) UnmarshalGQL(v any) error {
+	if v == nil {
+		return i.Set(nil)
+	}
+	parsed, err := graphql.UnmarshalInt64(v)
+	if err != nil {
+		return err
+	}
+	return i.Set(parsed)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Returns null when unset or null.
+func (i Int) MarshalJSON() ([]byte, error) {
+	if !i.set || i.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(i.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// A JSON null sets the explicit null state; a missing key never calls this
+// method and leaves the value unset (JSON Merge Patch semantics).
+// Accepts both JSON numbers and numeric strings.
+func (i *Int) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return i.Set(nil)
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		return i.Set(str)
+	}
+	var value int64
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid int JSON: %w", err)
+	}
+	return i.Set(value)
+}
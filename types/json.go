@@ -2,9 +2,12 @@ package bsgostuff_types
 
 import (
 	"bytes"
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"io"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -35,8 +38,14 @@ func NewJSONs(items []JSON) JSONs {
 	return JSONs(Slice[JSON]{items: items, set: true})
 }
 
-// Compile-time interface check
-var _ Settable[json.RawMessage, *structpb.Struct, pgtype.Text] = (*JSON)(nil)
+// Compile-time interface checks
+var (
+	_ Settable[json.RawMessage, *structpb.Struct, pgtype.Text] = (*JSON)(nil)
+	_ pgtype.TextScanner                                       = (*JSON)(nil)
+	_ pgtype.TextValuer                                        = JSON{}
+	_ graphql.Marshaler                                        = JSON{}
+	_ graphql.Unmarshaler                                      = (*JSON)(nil)
+)
 
 // NewJSON creates an initialized JSON value from raw JSON bytes.
 // Returns concrete type for method chaining.
@@ -175,6 +184,13 @@ func (j JSON) IsNull() bool {
 	return j.null
 }
 
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (j JSON) IsZero() bool {
+	return !j.set
+}
+
 // IsValid indicates whether the contained data is valid JSON.
 // Returns false when unset, null, or contains invalid JSON.
 func (j JSON) IsValid() bool {
@@ -250,3 +266,62 @@ func (j *JSON) UnmarshalJSON(data []byte) error {
 	j.data = data
 	return nil
 }
+
+// ScanText implements pgtype.TextScanner so pgx can scan text columns
+// directly into JSON. SQL NULL is mapped to the explicit null state.
+func (j *JSON) ScanText(v pgtype.Text) error {
+	return j.Set(v)
+}
+
+// TextValue implements pgtype.TextValuer so JSON can be bound to text parameters.
+// Unset and null values are encoded as SQL NULL.
+func (j JSON) TextValue() (pgtype.Text, error) {
+	return j.ToPgx(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// Used by pgx for json/jsonb columns. SQL NULL is mapped to the explicit null state.
+func (j *JSON) Scan(src any) error {
+	if v, ok := src.([]byte); ok {
+		// Driver memory is only valid until the next call, keep a copy
+		return j.Set(bytes.Clone(v))
+	}
+	return j.Set(src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns the raw JSON document or nil (SQL NULL) when unset or null.
+func (j JSON) Value() (driver.Value, error) {
+	if !j.set || j.null {
+		return nil, nil
+	}
+	if !j.valid {
+		return nil, fmt.Errorf("cannot encode invalid JSON")
+	}
+	return string(j.data), nil
+}
+
+// MarshalGQL implements graphql.Marshaler for gqlgen.
+// Writes the raw JSON document or null when unset, null or invalid.
+func (j JSON) MarshalGQL(w io.Writer) {
+	if !j.set || j.null || !j.valid {
+		graphql.Null.MarshalGQL(w)
+		return
+	}
+	_, _ = w.Write(j.data)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler for gqlgen.
+// gqlgen calls it only for arguments present in the request, so an absent
+// argument stays unset while an explicit null sets the null state.
+// Any decoded GraphQL value (object, list, scalar) is stored as JSON.
+func (j *JSON) UnmarshalGQL(v any) error {
+	if v == nil {
+		return j.Set(nil)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal GraphQL value to JSON: %w", err)
+	}
+	return j.Set(json.RawMessage(data))
+}
@@ -0,0 +1,434 @@
+package bsgostuff_types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"reflect"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Map implements a nullable generic map type with three-state logic:
+// - Set (with concrete entries, possibly empty)
+// - Explicitly set to null
+// - Unset (not initialized)
+//
+// Provides key-value storage for tags, labels and attributes with:
+// - Protocol Buffers support (Go maps are the native form of proto map fields)
+// - PostgreSQL jsonb storage (JSON object document)
+// - PostgreSQL hstore storage (keys and values converted to text)
+// - JSON serialization/deserialization
+//
+// Keys follow encoding/json rules: string kinds, integer kinds
+// or encoding.TextMarshaler implementations.
+//
+// Implements Settable[map[K]V, map[K]V, pgtype.Text] where:
+// - Proto representation is map[K]V (assignable to proto map fields)
+// - PostgreSQL storage uses the JSON document as pgtype.Text
+type Map[K comparable, V any] struct {
+	items map[K]V
+	set   bool
+	null  bool
+}
+
+// Compile-time interface checks
+var (
+	_ Settable[map[string]any, map[string]any, pgtype.Text] = (*Map[string, any])(nil)
+	_ pgtype.HstoreScanner                                  = (*Map[string, any])(nil)
+	_ pgtype.HstoreValuer                                   = Map[string, any]{}
+	_ graphql.Marshaler                                     = Map[string, any]{}
+	_ graphql.Unmarshaler                                   = (*Map[string, any])(nil)
+	_ json.Marshaler                                        = Map[string, any]{}
+	_ json.Unmarshaler                                      = (*Map[string, any])(nil)
+)
+
+// NewMap creates an initialized map value.
+// Returns concrete type for method chaining.
+func NewMap[K comparable, V any](items map[K]V) Map[K, V] {
+	return Map[K, V]{items: items, set: true}
+}
+
+// Set assigns a value from supported types:
+// - map[K]V: direct map value
+// - pgtype.Text: JSON object document, respects Valid flag
+// - pgtype.Hstore: nil treated as null, NULL values mapped to zero values
+// - string, []byte, json.RawMessage: JSON object document ("null" treated as null)
+// - *structpb.Struct: nil treated as null
+// - nil: explicit null
+// - Other map types: attempts key and value conversion
+func (m *Map[K, V]) Set(value any) error {
+	m.set = true
+	m.null = false
+	m.items = nil
+
+	switch v := value.(type) {
+	case map[K]V:
+		m.items = v
+	case pgtype.Text:
+		if !v.Valid {
+			m.null = true
+			return nil
+		}
+		return m.setJSON([]byte(v.String))
+	case pgtype.Hstore:
+		if v == nil {
+			m.null = true
+			return nil
+		}
+		return m.setHstore(v)
+	case string:
+		return m.setJSON([]byte(v))
+	case []byte:
+		return m.setJSON(v)
+	case json.RawMessage:
+		return m.setJSON(v)
+	case *structpb.Struct:
+		if v == nil {
+			m.null = true
+			return nil
+		}
+		data, err := v.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("failed to marshal protobuf Struct: %w", err)
+		}
+		return m.setJSON(data)
+	case nil:
+		m.null = true
+	default:
+		// Attempt conversion via reflection
+		val := reflect.ValueOf(value)
+		if val.Kind() != reflect.Map {
+			return fmt.Errorf("unsupported type: %T", value)
+		}
+		items := make(map[K]V, val.Len())
+		iterator := val.MapRange()
+		for iterator.Next() {
+			key, ok := iterator.Key().Interface().(K)
+			if !ok {
+				return fmt.Errorf("incompatible key type: %s", iterator.Key().Type())
+			}
+			item, ok := iterator.Value().Interface().(V)
+			if !ok {
+				return fmt.Errorf("incompatible value type for key %v: %s", key, iterator.Value().Type())
+			}
+			items[key] = item
+		}
+		m.items = items
+	}
+	return nil
+}
+
+// setJSON decodes a JSON object document into the map
+func (m *Map[K, V]) setJSON(data []byte) error {
+	if strings.TrimSpace(string(data)) == "null" {
+		m.null = true
+		return nil
+	}
+	var items map[K]V
+	if err := json.Unmarshal(data, &items); err != nil {
+		return fmt.Errorf("invalid JSON object: %w", err)
+	}
+	if items == nil {
+		items = map[K]V{}
+	}
+	m.items = items
+	return nil
+}
+
+// setHstore decodes hstore pairs into the map.
+// Text values are used as-is for string values and parsed as JSON otherwise.
+func (m *Map[K, V]) setHstore(h pgtype.Hstore) error {
+	stringValues := reflect.TypeFor[V]().Kind() == reflect.String
+	object := make(map[string]json.RawMessage, len(h))
+	for key, value := range h {
+		switch {
+		case value == nil:
+			object[key] = json.RawMessage("null")
+		case !stringValues && json.Valid([]byte(*value)):
+			object[key] = json.RawMessage(*value)
+		default:
+			quoted, err := json.Marshal(*value)
+			if err != nil {
+				return fmt.Errorf("failed to encode hstore value for key %q: %w", key, err)
+			}
+			object[key] = quoted
+		}
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return fmt.Errorf("failed to encode hstore: %w", err)
+	}
+	return m.setJSON(data)
+}
+
+// GetValue returns the underlying map.
+// Returns nil when unset or null.
+func (m Map[K, V]) GetValue() map[K]V {
+	if !m.set || m.null {
+		return nil
+	}
+	return m.items
+}
+
+// GetPtr returns a pointer to the map.
+// Returns nil when unset or null.
+func (m Map[K, V]) GetPtr() *map[K]V {
+	if !m.set || m.null {
+		return nil
+	}
+	return &m.items
+}
+
+// IsSet indicates whether the value was explicitly set.
+// Returns false for uninitialized zero values.
+func (m Map[K, V]) IsSet() bool {
+	return m.set
+}
+
+// IsNull indicates whether the value was explicitly set to null.
+// Returns false for unset values.
+func (m Map[K, V]) IsNull() bool {
+	return m.null
+}
+
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (m Map[K, V]) IsZero() bool {
+	return !m.set
+}
+
+// ToProto returns the map ready to be assigned to a proto map field.
+// Returns nil when unset or null.
+func (m Map[K, V]) ToProto() map[K]V {
+	return m.GetValue()
+}
+
+// ToPgx converts to JSON document as pgtype.Text for PostgreSQL jsonb integration.
+// Sets Valid flag according to null/unset state.
+// Returns invalid (NULL) value if entries cannot be encoded.
+func (m Map[K, V]) ToPgx() pgtype.Text {
+	if !m.set || m.null {
+		return pgtype.Text{Valid: false}
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return pgtype.Text{Valid: false}
+	}
+	return pgtype.Text{String: string(data), Valid: true}
+}
+
+// ToHstore converts to pgtype.Hstore for PostgreSQL hstore integration.
+// String values are stored as-is, other values as JSON text.
+// Returns nil (SQL NULL) when unset or null.
+func (m Map[K, V]) ToHstore() (pgtype.Hstore, error) {
+	if !m.set || m.null {
+		return nil, nil
+	}
+
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("failed to decode map: %w", err)
+	}
+
+	h := make(pgtype.Hstore, len(object))
+	for key, raw := range object {
+		switch {
+		case string(raw) == "null":
+			h[key] = nil
+		case raw[0] == '"':
+			var text string
+			if err := json.Unmarshal(raw, &text); err != nil {
+				return nil, fmt.Errorf("failed to decode value for key %q: %w", key, err)
+			}
+			h[key] = &text
+		default:
+			h[key] = ToPtr(string(raw))
+		}
+	}
+	return h, nil
+}
+
+// Len returns the number of entries in the map.
+// Returns 0 when unset or null.
+func (m Map[K, V]) Len() int {
+	if !m.set || m.null {
+		return 0
+	}
+	return len(m.items)
+}
+
+// Get returns the value stored under key and whether it was present.
+func (m Map[K, V]) Get(key K) (V, bool) {
+	value, ok := m.items[key]
+	return value, ok
+}
+
+// Put stores the value under key.
+// Initializes the map if not already set or null.
+func (m *Map[K, V]) Put(key K, value V) {
+	m.init()
+	m.items[key] = value
+}
+
+// Delete removes the entry stored under key.
+// Leaves unset and null maps unchanged.
+func (m *Map[K, V]) Delete(key K) {
+	delete(m.items, key)
+}
+
+// Merge copies entries from others into the map, later maps win on conflicts.
+// Unset and null maps in others are skipped.
+// Initializes the map if not already set or null.
+func (m *Map[K, V]) Merge(others ...Map[K, V]) {
+	m.init()
+	for _, other := range others {
+		if other.set && !other.null {
+			maps.Copy(m.items, other.items)
+		}
+	}
+}
+
+// Clone returns a shallow copy that does not share entries with the original.
+// Preserves unset and null states.
+func (m Map[K, V]) Clone() Map[K, V] {
+	if !m.set || m.null {
+		return m
+	}
+	return Map[K, V]{items: maps.Clone(m.items), set: true}
+}
+
+// All returns an iterator over key-value pairs in unspecified order.
+// Yields nothing when unset or null.
+func (m Map[K, V]) All() iter.Seq2[K, V] {
+	return maps.All(m.GetValue())
+}
+
+// Keys returns an iterator over keys in unspecified order.
+// Yields nothing when unset or null.
+func (m Map[K, V]) Keys() iter.Seq[K] {
+	return maps.Keys(m.GetValue())
+}
+
+// Values returns an iterator over values in unspecified order.
+// Yields nothing when unset or null.
+func (m Map[K, V]) Values() iter.Seq[V] {
+	return maps.Values(m.GetValue())
+}
+
+// init marks the map as set and allocates storage when needed
+func (m *Map[K, V]) init() {
+	m.set = true
+	m.null = false
+	if m.items == nil {
+		m.items = make(map[K]V)
+	}
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Returns JSON object or null when unset or null.
+func (m Map[K, V]) MarshalJSON() ([]byte, error) {
+	if !m.set || m.null {
+		return []byte("null"), nil
+	}
+	if m.items == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m.items)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// A JSON null sets the explicit null state; a missing key never calls this
+// method and leaves the value unset (JSON Merge Patch semantics).
+func (m *Map[K, V]) UnmarshalJSON(data []byte) error {
+	return m.Set(data)
+}
+
+// ScanHstore implements pgtype.HstoreScanner so pgx can scan hstore
+// columns directly into Map. SQL NULL is mapped to the explicit null state.
+func (m *Map[K, V]) ScanHstore(v pgtype.Hstore) error {
+	return m.Set(v)
+}
+
+// HstoreValue implements pgtype.HstoreValuer so Map can be bound to hstore parameters.
+// Unset and null values are encoded as SQL NULL.
+func (m Map[K, V]) HstoreValue() (pgtype.Hstore, error) {
+	return m.ToHstore()
+}
+
+// Scan implements the database/sql Scanner interface.
+// Used by pgx for json/jsonb columns; hstore text is accepted as well.
+// SQL NULL is mapped to the explicit null state.
+func (m *Map[K, V]) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return m.Set(src)
+	}
+
+	trimmed := strings.TrimSpace(text)
+	if strings.HasPrefix(trimmed, "{") || trimmed == "null" {
+		return m.Set(text)
+	}
+
+	var h pgtype.Hstore
+	if err := h.Scan(text); err != nil {
+		return fmt.Errorf("failed to parse hstore: %w", err)
+	}
+	return m.Set(h)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns the JSON object document or nil (SQL NULL) when unset or null.
+func (m Map[K, V]) Value() (driver.Value, error) {
+	if !m.set || m.null {
+		return nil, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// MarshalGQL implements graphql.Marshaler for gqlgen.
+// Writes JSON object or null when unset or null.
+func (m Map[K, V]) MarshalGQL(w io.Writer) {
+	data, err := m.MarshalJSON()
+	if err != nil {
+		graphql.Null.MarshalGQL(w)
+		return
+	}
+	_, _ = w.Write(data)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler for gqlgen.
+// gqlgen calls it only for arguments present in the request, so an absent
+// argument stays unset while an explicit null sets the null state.
+// Accepts GraphQL input objects.
+func (m *Map[K, V]) UnmarshalGQL(v any) error {
+	if v == nil {
+		return m.Set(nil)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal GraphQL value to JSON: %w", err)
+	}
+	return m.Set(json.RawMessage(data))
+}
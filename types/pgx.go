@@ -0,0 +1,47 @@
+package bsgostuff_types
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+// pgTypeNames maps the concrete nullable types to their PostgreSQL type names.
+// Each entry is registered together with its Go slice type as the array type.
+// Generic types (Enum, Map, Ref, Slice, Struct) are omitted: their Go types
+// are only known at instantiation time.
+var pgTypeNames = []struct {
+	value any
+	array any
+	name  string
+}{
+	{Bool{}, []Bool{}, "bool"},
+	{Bytes{}, []Bytes{}, "bytea"},
+	{Date{}, []Date{}, "date"},
+	{Decimal{}, []Decimal{}, "numeric"},
+	{Duration{}, []Duration{}, "interval"},
+	{Float{}, []Float{}, "float8"},
+	{ID{}, []ID{}, "uuid"},
+	{Int{}, []Int{}, "int8"},
+	{JSON{}, []JSON{}, "jsonb"},
+	{String{}, []String{}, "text"},
+	{TimeOfDay{}, []TimeOfDay{}, "time"},
+	{Timestamp{}, []Timestamp{}, "timestamp"},
+	{TimestampTZ{}, []TimestampTZ{}, "timestamptz"},
+}
+
+// RegisterPgTypes registers the nullable types with a pgx type map.
+//
+// Parameters are encoded through the pgtype Valuer interfaces even without
+// registration. Registration lets pgx resolve the PostgreSQL type when the OID
+// is unknown: simple protocol and exec query modes, and arrays of these types
+// (e.g. []ID as uuid[]).
+//
+// Call it for every connection, typically from pgxpool.Config.AfterConnect:
+//
+//	cfg.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		bsgostuff_types.RegisterPgTypes(conn.TypeMap())
+//		return nil
+//	}
+func RegisterPgTypes(m *pgtype.Map) {
+	for _, t := range pgTypeNames {
+		m.RegisterDefaultPgType(t.value, t.name)
+		m.RegisterDefaultPgType(t.array, "_"+t.name)
+	}
+}
@@ -1,6 +1,8 @@
 package bsgostuff_types
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -29,8 +31,12 @@ type Slice[T any] struct {
 	null  bool
 }
 
-// Compile-time interface check
-var _ Settable[[]any, []proto.Message, pgtype.Array[any]] = (*Slice[any])(nil)
+// Compile-time interface checks
+var (
+	_ Settable[[]any, []proto.Message, pgtype.Array[any]] = (*Slice[any])(nil)
+	_ pgtype.ArraySetter                                  = (*Slice[any])(nil)
+	_ pgtype.ArrayGetter                                  = Slice[any]{}
+)
 
 // NewSlice creates an initialized slice value.
 // Returns concrete type for method chaining.
@@ -127,6 +133,13 @@ func (s Slice[T]) IsNull() bool {
 	return s.null
 }
 
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (s Slice[T]) IsZero() bool {
+	return !s.set
+}
+
 // ToProto converts to []proto.Message if elements are proto.Message.
 // Returns nil when unset or null or for non-protobuf elements.
 func (s Slice[T]) ToProto() []proto.Message {
@@ -179,6 +192,138 @@ func (s *Slice[T]) UnmarshalJSON(data []byte) error {
 	return s.Set(items)
 }
 
+// Dimensions implements pgtype.ArrayGetter.
+// Returns nil (SQL NULL) when unset or null.
+func (s Slice[T]) Dimensions() []pgtype.ArrayDimension {
+	if !s.set || s.null {
+		return nil
+	}
+	return []pgtype.ArrayDimension{{Length: int32(len(s.items)), LowerBound: 1}}
+}
+
+// Index implements pgtype.ArrayGetter.
+func (s Slice[T]) Index(i int) any {
+	return s.items[i]
+}
+
+// IndexType implements pgtype.ArrayGetter.
+func (s Slice[T]) IndexType() any {
+	var zero T
+	return zero
+}
+
+// SetDimensions implements pgtype.ArraySetter.
+// Nil dimensions (SQL NULL) are mapped to the explicit null state.
+// Multi-dimensional arrays are flattened.
+func (s *Slice[T]) SetDimensions(dimensions []pgtype.ArrayDimension) error {
+	s.set = true
+	s.null = dimensions == nil
+	s.items = nil
+
+	if dimensions == nil {
+		return nil
+	}
+
+	count := 0
+	if len(dimensions) > 0 {
+		count = 1
+		for _, dim := range dimensions {
+			count *= int(dim.Length)
+		}
+	}
+	s.items = make([]T, count)
+	return nil
+}
+
+// ScanIndex implements pgtype.ArraySetter.
+func (s Slice[T]) ScanIndex(i int) any {
+	return &s.items[i]
+}
+
+// ScanIndexType implements pgtype.ArraySetter.
+func (s Slice[T]) ScanIndexType() any {
+	return new(T)
+}
+
+// Scan implements the database/sql Scanner interface.
+// Accepts PostgreSQL array literals (e.g. {a,b,NULL}) and native slices.
+// SQL NULL is mapped to the explicit null state.
+func (s *Slice[T]) Scan(src any) error {
+	var literal []byte
+	switch v := src.(type) {
+	case string:
+		literal = []byte(v)
+	case []byte:
+		literal = v
+	default:
+		return s.Set(src)
+	}
+
+	m := pgtype.NewMap()
+	var elements []*string
+	if err := m.Scan(pgtype.TextArrayOID, pgtype.TextFormatCode, literal, &elements); err != nil {
+		return fmt.Errorf("failed to parse array literal: %w", err)
+	}
+
+	items := make([]T, len(elements))
+	for i, elem := range elements {
+		if err := scanArrayElement(m, &items[i], elem); err != nil {
+			return fmt.Errorf("failed to scan element %d: %w", i, err)
+		}
+	}
+	return s.Set(items)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Encodes the elements as a PostgreSQL array literal.
+// Returns nil (SQL NULL) when unset or null.
+func (s Slice[T]) Value() (driver.Value, error) {
+	if !s.set || s.null {
+		return nil, nil
+	}
+
+	m := pgtype.NewMap()
+	elements := make([]*string, len(s.items))
+	for i, item := range s.items {
+		// Non-nil buffer distinguishes empty strings from NULL elements
+		buf, err := m.Encode(0, pgtype.TextFormatCode, item, make([]byte, 0))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode element %d: %w", i, err)
+		}
+		if buf != nil {
+			elements[i] = ToPtr(string(buf))
+		}
+	}
+
+	buf, err := m.Encode(pgtype.TextArrayOID, pgtype.TextFormatCode, elements, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode array: %w", err)
+	}
+	return string(buf), nil
+}
+
+// scanArrayElement scans a single text array element into dst,
+// preferring sql.Scanner and falling back to registered pgx types.
+func scanArrayElement(m *pgtype.Map, dst any, src *string) error {
+	if scanner, ok := dst.(sql.Scanner); ok {
+		if src == nil {
+			return scanner.Scan(nil)
+		}
+		return scanner.Scan(*src)
+	}
+
+	dataType, ok := m.TypeForValue(dst)
+	if !ok {
+		return fmt.Errorf("unsupported element type: %T", dst)
+	}
+
+	var buf []byte
+	if src != nil {
+		buf = []byte(*src)
+	}
+	return m.Scan(dataType.OID, pgtype.TextFormatCode, buf, dst)
+}
+
 // Append adds elements to the slice.
 // Initializes the slice if not already set.
 func (s *Slice[T]) Append(elements ...T) {
@@ -1,8 +1,12 @@
 package bsgostuff_types
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"io"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -25,8 +29,16 @@ func NewStrings(items []string) Strings {
 	return Strings(Slice[string]{items: items, set: true})
 }
 
-// Compile-time interface check
-var _ Settable[string, *wrapperspb.StringValue, pgtype.Text] = (*String)(nil)
+// Compile-time interface checks
+var (
+	_ Settable[string, *wrapperspb.StringValue, pgtype.Text] = (*String)(nil)
+	_ pgtype.TextScanner                                     = (*String)(nil)
+	_ pgtype.TextValuer                                      = String{}
+	_ graphql.Marshaler                                      = String{}
+	_ graphql.Unmarshaler                                    = (*String)(nil)
+	_ json.Marshaler                                         = String{}
+	_ json.Unmarshaler                                       = (*String)(nil)
+)
 
 // NewString creates an initialized string value.
 // Returns concrete type for method chaining.
@@ -91,6 +103,13 @@ func (s String) IsNull() bool {
 	return s.null
 }
 
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (s String) IsZero() bool {
+	return !s.set
+}
+
 // ToProto converts to protobuf StringValue wrapper.
 // Returns nil when unset or null.
 func (s String) ToProto() *wrapperspb.StringValue {
@@ -108,3 +127,80 @@ func (s String) ToPgx() pgtype.Text {
 		Valid:  s.set && !s.null,
 	}
 }
+
+// ScanText implements pgtype.TextScanner so pgx can scan text-like columns
+// directly into String. SQL NULL is mapped to the explicit null state.
+func (s *String) ScanText(v pgtype.Text) error {
+	return s.Set(v)
+}
+
+// TextValue implements pgtype.TextValuer so String can be used as a query argument.
+// Unset and null values are encoded as SQL NULL.
+func (s String) TextValue() (pgtype.Text, error) {
+	return s.ToPgx(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// SQL NULL is mapped to the explicit null state.
+func (s *String) Scan(src any) error {
+	if v, ok := src.([]byte); ok {
+		return s.Set(string(v))
+	}
+	return s.Set(src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns nil (SQL NULL) when unset or null.
+func (s String) Value() (driver.Value, error) {
+	if !s.set || s.null {
+		return nil, nil
+	}
+	return s.value, nil
+}
+
+// MarshalGQL implements graphql.Marshaler for gqlgen.
+// Writes null when unset or null.
+func (s String) MarshalGQL(w io.Writer) {
+	if !s.set || s.null {
+		graphql.Null.MarshalGQL(w)
+		return
+	}
+	graphql.MarshalString(s.value).MarshalGQL(w)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler for gqlgen.
+// gqlgen calls it only for arguments present in the request, so an absent
+// argument stays unset while an explicit null sets the null state.
+func (s *String) UnmarshalGQL(v any) error {
+	if v == nil {
+		return s.Set(nil)
+	}
+	parsed, err := graphql.UnmarshalString(v)
+	if err != nil {
+		return err
+	}
+	return s.Set(parsed)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Returns null when unset or null.
+func (s String) MarshalJSON() ([]byte, error) {
+	if !s.set || s.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// A JSON null sets the explicit null state; a missing key never calls this
+// method and leaves the value unset (JSON Merge Patch semantics).
+func (s *String) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return s.Set(nil)
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid string JSON: %w", err)
+	}
+	return s.Set(value)
+}
@@ -1,6 +1,7 @@
 package bsgostuff_types
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 
@@ -35,8 +36,12 @@ func NewStructs[T any](items []Struct[T]) Structs[T] {
 	return Structs[T](Slice[Struct[T]]{items: items, set: true})
 }
 
-// Compile-time interface check
-var _ Settable[any, proto.Message, pgtype.Text] = (*Struct[any])(nil)
+// Compile-time interface checks
+var (
+	_ Settable[any, proto.Message, pgtype.Text] = (*Struct[any])(nil)
+	_ pgtype.TextScanner                        = (*Struct[any])(nil)
+	_ pgtype.TextValuer                         = Struct[any]{}
+)
 
 // NewStruct creates an initialized struct value.
 // Returns concrete type for method chaining.
@@ -150,6 +155,13 @@ func (s Struct[T]) IsNull() bool {
 	return s.null
 }
 
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (s Struct[T]) IsZero() bool {
+	return !s.set
+}
+
 // ToProto converts to protobuf Message if the struct is a proto.Message.
 // Returns nil when unset or null or for non-protobuf structs.
 func (s Struct[T]) ToProto() proto.Message {
@@ -170,16 +182,7 @@ func (s Struct[T]) ToPgx() pgtype.Text {
 		return pgtype.Text{Valid: false}
 	}
 
-	var data []byte
-	var err error
-
-	// Use protojson for protobuf messages
-	if msg, ok := any(*s.value).(proto.Message); ok {
-		data, err = protojson.Marshal(msg)
-	} else {
-		data, err = json.Marshal(s.value)
-	}
-
+	data, err := s.marshalValue()
 	if err != nil {
 		return pgtype.Text{Valid: false}
 	}
@@ -190,6 +193,15 @@ func (s Struct[T]) ToPgx() pgtype.Text {
 	}
 }
 
+// marshalValue serializes the struct for storage,
+// using protojson for protobuf messages and encoding/json otherwise.
+func (s Struct[T]) marshalValue() ([]byte, error) {
+	if msg, ok := any(*s.value).(proto.Message); ok {
+		return protojson.Marshal(msg)
+	}
+	return json.Marshal(s.value)
+}
+
 // MarshalJSON implements json.Marshaler interface
 func (s Struct[T]) MarshalJSON() ([]byte, error) {
 	if !s.set || s.null {
@@ -208,3 +220,41 @@ func (s *Struct[T]) UnmarshalJSON(data []byte) error {
 	}
 	return s.Set(data)
 }
+
+// ScanText implements pgtype.TextScanner so pgx can scan text columns
+// directly into Struct. SQL NULL is mapped to the explicit null state.
+func (s *Struct[T]) ScanText(v pgtype.Text) error {
+	return s.Set(v)
+}
+
+// TextValue implements pgtype.TextValuer so Struct can be bound to text parameters.
+// Unset and null values are encoded as SQL NULL.
+func (s Struct[T]) TextValue() (pgtype.Text, error) {
+	if !s.set || s.null {
+		return pgtype.Text{Valid: false}, nil
+	}
+	data, err := s.marshalValue()
+	if err != nil {
+		return pgtype.Text{}, fmt.Errorf("failed to marshal struct: %w", err)
+	}
+	return pgtype.Text{String: string(data), Valid: true}, nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// Used by pgx for json/jsonb columns. SQL NULL is mapped to the explicit null state.
+func (s *Struct[T]) Scan(src any) error {
+	return s.Set(src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns the JSON document or nil (SQL NULL) when unset or null.
+func (s Struct[T]) Value() (driver.Value, error) {
+	if !s.set || s.null {
+		return nil, nil
+	}
+	data, err := s.marshalValue()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal struct: %w", err)
+	}
+	return string(data), nil
+}
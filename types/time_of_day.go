@@ -0,0 +1,285 @@
+package bsgostuff_types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/genproto/googleapis/type/timeofday"
+)
+
+// EndOfDay is the upper bound (inclusive) of TimeOfDay, matching PostgreSQL "24:00:00".
+const EndOfDay = 24 * time.Hour
+
+// TimeOfDay implements a nullable wall-clock time (without date and zone) with three-state logic:
+// - Set (with concrete value)
+// - Explicitly set to null
+// - Unset (not initialized)
+//
+// Suitable for opening hours, schedules and other daily recurring times.
+// The value is stored as time.Duration since midnight with microsecond
+// precision (PostgreSQL time resolution).
+//
+// Implements Settable[time.Duration, *timeofday.TimeOfDay, pgtype.Time]
+type TimeOfDay struct {
+	value time.Duration
+	set   bool
+	null  bool
+}
+
+type TimesOfDay = Slice[TimeOfDay]
+
+func NewTimesOfDay(items []TimeOfDay) TimesOfDay {
+	return TimesOfDay(Slice[TimeOfDay]{items: items, set: true})
+}
+
+// Compile-time interface checks
+var (
+	_ Settable[time.Duration, *timeofday.TimeOfDay, pgtype.Time] = (*TimeOfDay)(nil)
+	_ pgtype.TimeScanner                                         = (*TimeOfDay)(nil)
+	_ pgtype.TimeValuer                                          = TimeOfDay{}
+	_ graphql.Marshaler                                          = TimeOfDay{}
+	_ graphql.Unmarshaler                                        = (*TimeOfDay)(nil)
+	_ json.Marshaler                                             = TimeOfDay{}
+	_ json.Unmarshaler                                           = (*TimeOfDay)(nil)
+)
+
+// timeOfDayLayouts lists accepted string formats, most specific last
+var timeOfDayLayouts = []string{"15:04", "15:04:05", "15:04:05.999999999"}
+
+// NewTimeOfDay creates an initialized time of day from clock components.
+// Returns error if the resulting time is outside [00:00, 24:00].
+func NewTimeOfDay(hour, minute, second int) (TimeOfDay, error) {
+	t := TimeOfDay{}
+	err := t.Set(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second)
+	return t, err
+}
+
+// Set assigns a value from supported types:
+// - time.Duration: offset since midnight, must be within [0, 24h]
+// - *time.Duration: nil pointer treated as null
+// - time.Time: clock part in the value's location
+// - string: "15:04", "15:04:05" or "15:04:05.999999" (empty string treated as null)
+// - pgtype.Time: respects Valid flag
+// - *timeofday.TimeOfDay: google.type.TimeOfDay, nil treated as null
+// - nil: explicit null
+func (t *TimeOfDay) Set(value any) error {
+	t.set = true
+	t.null = false
+	t.value = 0
+
+	switch v := value.(type) {
+	case time.Duration:
+		return t.setDuration(v)
+	case *time.Duration:
+		if v == nil {
+			t.null = true
+			return nil
+		}
+		return t.setDuration(*v)
+	case time.Time:
+		hour, minute, second := v.Clock()
+		return t.setDuration(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute +
+			time.Duration(second)*time.Second + time.Duration(v.Nanosecond()))
+	case string:
+		if v == "" {
+			t.null = true
+			return nil
+		}
+		if v == "24:00" || v == "24:00:00" {
+			return t.setDuration(EndOfDay)
+		}
+		for _, layout := range timeOfDayLayouts {
+			if parsed, err := time.Parse(layout, v); err == nil {
+				return t.Set(parsed)
+			}
+		}
+		return fmt.Errorf("failed to parse time of day string: %q", v)
+	case pgtype.Time:
+		if !v.Valid {
+			t.null = true
+			return nil
+		}
+		return t.setDuration(time.Duration(v.Microseconds) * time.Microsecond)
+	case *timeofday.TimeOfDay:
+		if v == nil {
+			t.null = true
+			return nil
+		}
+		return t.setDuration(time.Duration(v.GetHours())*time.Hour + time.Duration(v.GetMinutes())*time.Minute +
+			time.Duration(v.GetSeconds())*time.Second + time.Duration(v.GetNanos()))
+	case nil:
+		t.null = true
+	default:
+		return fmt.Errorf("unsupported type: %T", value)
+	}
+	return nil
+}
+
+// setDuration validates range and truncates to microsecond precision
+func (t *TimeOfDay) setDuration(d time.Duration) error {
+	if d < 0 || d > EndOfDay {
+		return fmt.Errorf("time of day out of range: %s", d)
+	}
+	t.value = d.Truncate(time.Microsecond)
+	return nil
+}
+
+// GetValue returns the offset since midnight.
+// Returns 0 when unset or null.
+func (t TimeOfDay) GetValue() time.Duration {
+	return t.value
+}
+
+// GetPtr returns a pointer to the offset since midnight.
+// Returns nil when unset or null.
+func (t TimeOfDay) GetPtr() *time.Duration {
+	if !t.set || t.null {
+		return nil
+	}
+	return &t.value
+}
+
+// IsSet indicates whether the value was explicitly set.
+// Returns false for uninitialized zero values.
+func (t TimeOfDay) IsSet() bool {
+	return t.set
+}
+
+// IsNull indicates whether the value was explicitly set to null.
+// Returns false for unset values.
+func (t TimeOfDay) IsNull() bool {
+	return t.null
+}
+
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (t TimeOfDay) IsZero() bool {
+	return !t.set
+}
+
+// ToProto converts to google.type.TimeOfDay.
+// Returns nil when unset or null.
+func (t TimeOfDay) ToProto() *timeofday.TimeOfDay {
+	if !t.set || t.null {
+		return nil
+	}
+	return &timeofday.TimeOfDay{
+		Hours:   int32(t.value / time.Hour),
+		Minutes: int32(t.value % time.Hour / time.Minute),
+		Seconds: int32(t.value % time.Minute / time.Second),
+		Nanos:   int32(t.value % time.Second),
+	}
+}
+
+// ToPgx converts to pgtype.Time for PostgreSQL integration.
+// Sets Valid flag according to null/unset state.
+func (t TimeOfDay) ToPgx() pgtype.Time {
+	return pgtype.Time{
+		Microseconds: t.value.Microseconds(),
+		Valid:        t.set && !t.null,
+	}
+}
+
+// String returns the time in "15:04:05" format with optional fractional seconds.
+// Returns empty string when unset or null.
+func (t TimeOfDay) String() string {
+	if !t.set || t.null {
+		return ""
+	}
+	if t.value == EndOfDay {
+		return "24:00:00"
+	}
+	return time.Time{}.Add(t.value).Format("15:04:05.999999")
+}
+
+// On returns the moment of this time of day on the given date in loc.
+// Returns zero time when unset or null.
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	if !t.set || t.null || !d.IsSet() || d.IsNull() {
+		return time.Time{}
+	}
+	return d.In(loc).Add(t.value)
+}
+
+// ScanTime implements pgtype.TimeScanner so pgx can scan time columns
+// directly into TimeOfDay. SQL NULL is mapped to the explicit null state.
+func (t *TimeOfDay) ScanTime(v pgtype.Time) error {
+	return t.Set(v)
+}
+
+// TimeValue implements pgtype.TimeValuer so TimeOfDay can be used as a query argument.
+// Unset and null values are encoded as SQL NULL.
+func (t TimeOfDay) TimeValue() (pgtype.Time, error) {
+	return t.ToPgx(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// SQL NULL is mapped to the explicit null state.
+func (t *TimeOfDay) Scan(src any) error {
+	if v, ok := src.([]byte); ok {
+		return t.Set(string(v))
+	}
+	return t.Set(src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns "15:04:05" string or nil (SQL NULL) when unset or null.
+func (t TimeOfDay) Value() (driver.Value, error) {
+	if !t.set || t.null {
+		return nil, nil
+	}
+	return t.String(), nil
+}
+
+// MarshalGQL implements graphql.Marshaler for gqlgen.
+// Writes "15:04:05" string or null when unset or null.
+func (t TimeOfDay) MarshalGQL(w io.Writer) {
+	if !t.set || t.null {
+		graphql.Null.MarshalGQL(w)
+		return
+	}
+	graphql.MarshalString(t.String()).MarshalGQL(w)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler for gqlgen.
+// gqlgen calls it only for arguments present in the request, so an absent
+// argument stays unset while an explicit null sets the null state.
+func (t *TimeOfDay) UnmarshalGQL(v any) error {
+	if v == nil {
+		return t.Set(nil)
+	}
+	parsed, err := graphql.UnmarshalString(v)
+	if err != nil {
+		return err
+	}
+	return t.Set(parsed)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Returns "15:04:05" string or null when unset or null.
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	if !t.set || t.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// A JSON null sets the explicit null state; a missing key never calls this
+// method and leaves the value unset (JSON Merge Patch semantics).
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return t.Set(nil)
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid time of day JSON: %w", err)
+	}
+	return t.Set(value)
+}
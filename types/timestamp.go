@@ -1,10 +1,14 @@
 package bsgostuff_types
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"io"
 	"strconv"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -33,8 +37,18 @@ func NewTimestamps(items []Timestamp) Timestamps {
 	return Timestamps(Slice[Timestamp]{items: items, set: true})
 }
 
-// Compile-time interface check
-var _ Settable[time.Time, *timestamppb.Timestamp, pgtype.Timestamp] = (*Timestamp)(nil)
+// Compile-time interface checks
+var (
+	_ Settable[time.Time, *timestamppb.Timestamp, pgtype.Timestamp] = (*Timestamp)(nil)
+	_ pgtype.TimestampScanner                                       = (*Timestamp)(nil)
+	_ pgtype.TimestampValuer                                        = Timestamp{}
+	_ pgtype.TimestamptzScanner                                     = (*Timestamp)(nil)
+	_ pgtype.TimestamptzValuer                                      = Timestamp{}
+	_ graphql.Marshaler                                             = Timestamp{}
+	_ graphql.Unmarshaler                                           = (*Timestamp)(nil)
+	_ json.Marshaler                                                = Timestamp{}
+	_ json.Unmarshaler                                              = (*Timestamp)(nil)
+)
 
 // NewTimestamp creates an initialized timestamp value.
 // Returns concrete type for method chaining.
@@ -48,6 +62,7 @@ func NewTimestamp(value time.Time) Timestamp {
 // - string: parsed as RFC3339 or Unix timestamp
 // - int64: treated as Unix timestamp (seconds since epoch)
 // - pgtype.Timestamp: respects Valid flag
+// - pgtype.Timestamptz: respects Valid flag
 // - timestamppb.Timestamp: protobuf timestamp
 // - nil: explicit null
 func (t *Timestamp) Set(value any) error {
@@ -88,6 +103,9 @@ func (t *Timestamp) Set(value any) error {
 	case pgtype.Timestamp:
 		t.null = !v.Valid
 		t.value = v.Time
+	case pgtype.Timestamptz:
+		t.null = !v.Valid
+		t.value = v.Time
 	case *timestamppb.Timestamp:
 		if v == nil {
 			t.null = true
@@ -131,6 +149,13 @@ func (t Timestamp) IsNull() bool {
 	return t.null
 }
 
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (t Timestamp) IsZero() bool {
+	return !t.set
+}
+
 // ToProto converts to protobuf Timestamp.
 // Returns nil when unset or null.
 func (t Timestamp) ToProto() *timestamppb.Timestamp {
@@ -149,6 +174,51 @@ func (t Timestamp) ToPgx() pgtype.Timestamp {
 	}
 }
 
+// ScanTimestamp implements pgtype.TimestampScanner so pgx can scan timestamp
+// columns directly into Timestamp. SQL NULL is mapped to the explicit null state.
+func (t *Timestamp) ScanTimestamp(v pgtype.Timestamp) error {
+	return t.Set(v)
+}
+
+// TimestampValue implements pgtype.TimestampValuer so Timestamp can be used
+// as a query argument. Unset and null values are encoded as SQL NULL.
+func (t Timestamp) TimestampValue() (pgtype.Timestamp, error) {
+	return t.ToPgx(), nil
+}
+
+// ScanTimestamptz implements pgtype.TimestamptzScanner so pgx can scan
+// timestamptz columns directly into Timestamp.
+func (t *Timestamp) ScanTimestamptz(v pgtype.Timestamptz) error {
+	return t.Set(v)
+}
+
+// TimestamptzValue implements pgtype.TimestamptzValuer so Timestamp can be
+// bound to timestamptz parameters. Unset and null values are encoded as SQL NULL.
+func (t Timestamp) TimestamptzValue() (pgtype.Timestamptz, error) {
+	return pgtype.Timestamptz{
+		Time:  t.value,
+		Valid: t.set && !t.null,
+	}, nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// SQL NULL is mapped to the explicit null state.
+func (t *Timestamp) Scan(src any) error {
+	if v, ok := src.([]byte); ok {
+		return t.Set(string(v))
+	}
+	return t.Set(src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns nil (SQL NULL) when unset or null.
+func (t Timestamp) Value() (driver.Value, error) {
+	if !t.set || t.null {
+		return nil, nil
+	}
+	return t.value, nil
+}
+
 // Unix returns the Unix timestamp (seconds since January 1, 1970 UTC).
 // Returns 0 when unset or null.
 func (t Timestamp) Unix() int64 {
@@ -196,3 +266,58 @@ func NewTimestampFromUnixNano(nsec int64) Timestamp {
 func NewCurrentTimestamp() Timestamp {
 	return NewTimestamp(time.Now())
 }
+
+// MarshalGQL implements graphql.Marshaler for gqlgen.
+// Writes RFC3339 string or null when unset or null.
+func (t Timestamp) MarshalGQL(w io.Writer) {
+	if !t.set || t.null {
+		graphql.Null.MarshalGQL(w)
+		return
+	}
+	graphql.MarshalString(t.value.Format(time.RFC3339Nano)).MarshalGQL(w)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler for gqlgen.
+// gqlgen calls it only for arguments present in the request, so an absent
+// argument stays unset while an explicit null sets the null state.
+// Accepts RFC3339 strings and Unix timestamps (seconds).
+func (t *Timestamp) UnmarshalGQL(v any) error {
+	switch v := v.(type) {
+	case nil, string:
+		return t.Set(v)
+	default:
+		unix, err := graphql.UnmarshalInt64(v)
+		if err != nil {
+			return fmt.Errorf("timestamp should be RFC3339 string or Unix timestamp: %w", err)
+		}
+		return t.Set(unix)
+	}
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Returns RFC3339 string or null when unset or null.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if !t.set || t.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.value.Format(time.RFC3339Nano))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// A JSON null sets the explicit null state; a missing key never calls this
+// method and leaves the value unset (JSON Merge Patch semantics).
+// Accepts RFC3339 strings and Unix timestamps (seconds).
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return t.Set(nil)
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		return t.Set(str)
+	}
+	var unix int64
+	if err := json.Unmarshal(data, &unix); err != nil {
+		return fmt.Errorf("timestamp should be RFC3339 string or Unix timestamp: %w", err)
+	}
+	return t.Set(unix)
+}
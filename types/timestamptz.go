@@ -0,0 +1,321 @@
+package bsgostuff_types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TimestampTZ implements a nullable zone-aware time.Time with three-state logic:
+// - Set (with concrete value)
+// - Explicitly set to null
+// - Unset (not initialized)
+//
+// Unlike Timestamp (PostgreSQL timestamp without time zone, which stores
+// the wall clock and drops the offset), TimestampTZ represents an absolute
+// instant and maps to timestamptz:
+// - values keep the location they were set with (e.g. parsed RFC3339 offset)
+// - values read from PostgreSQL and protobuf are normalized to UTC
+//
+// Implements Settable[time.Time, *timestamppb.Timestamp, pgtype.Timestamptz]
+type TimestampTZ struct {
+	value time.Time
+	set   bool
+	null  bool
+}
+
+type TimestampTZs = Slice[TimestampTZ]
+
+func NewTimestampTZs(items []TimestampTZ) TimestampTZs {
+	return TimestampTZs(Slice[TimestampTZ]{items: items, set: true})
+}
+
+// Compile-time interface checks
+var (
+	_ Settable[time.Time, *timestamppb.Timestamp, pgtype.Timestamptz] = (*TimestampTZ)(nil)
+	_ pgtype.TimestamptzScanner                                       = (*TimestampTZ)(nil)
+	_ pgtype.TimestamptzValuer                                        = TimestampTZ{}
+	_ graphql.Marshaler                                               = TimestampTZ{}
+	_ graphql.Unmarshaler                                             = (*TimestampTZ)(nil)
+	_ json.Marshaler                                                  = TimestampTZ{}
+	_ json.Unmarshaler                                                = (*TimestampTZ)(nil)
+)
+
+// NewTimestampTZ creates an initialized zone-aware timestamp value.
+// Returns concrete type for method chaining.
+func NewTimestampTZ(value time.Time) TimestampTZ {
+	return TimestampTZ{value: value, set: true}
+}
+
+// NewCurrentTimestampTZ creates a new TimestampTZ with current UTC time.
+func NewCurrentTimestampTZ() TimestampTZ {
+	return NewTimestampTZ(time.Now().UTC())
+}
+
+// Set assigns a value from supported types:
+// - time.Time: direct value, location preserved
+// - *time.Time: nil pointer treated as null
+// - string: parsed as RFC3339 (offset preserved) or Unix timestamp
+// - int64: treated as Unix timestamp (seconds since epoch), UTC
+// - pgtype.Timestamptz: respects Valid flag, normalized to UTC
+// - pgtype.Timestamp: wall clock interpreted as UTC
+// - Timestamp: wall clock value of the zone-less variant
+// - *timestamppb.Timestamp: nil treated as null, UTC
+// - nil: explicit null
+func (t *TimestampTZ) Set(value any) error {
+	t.set = true
+	t.null = false
+	t.value = time.Time{}
+
+	switch v := value.(type) {
+	case time.Time:
+		t.value = v
+	case *time.Time:
+		if v == nil {
+			t.null = true
+		} else {
+			t.value = *v
+		}
+	case string:
+		if v == "" {
+			t.null = true
+			return nil
+		}
+
+		// Try RFC3339 first
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err == nil {
+			t.value = parsed
+			return nil
+		}
+
+		// Fall back to Unix timestamp
+		unix, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse timestamp string (neither RFC3339 nor Unix timestamp): %w", err)
+		}
+		t.value = time.Unix(unix, 0).UTC()
+	case int64:
+		t.value = time.Unix(v, 0).UTC()
+	case pgtype.Timestamptz:
+		t.null = !v.Valid
+		t.value = v.Time.UTC()
+	case pgtype.Timestamp:
+		t.null = !v.Valid
+		year, month, day := v.Time.Date()
+		hour, minute, second := v.Time.Clock()
+		t.value = time.Date(year, month, day, hour, minute, second, v.Time.Nanosecond(), time.UTC)
+	case Timestamp:
+		t.null = v.IsNull() || !v.IsSet()
+		t.value = v.GetValue()
+	case *timestamppb.Timestamp:
+		if v == nil {
+			t.null = true
+		} else {
+			t.value = v.AsTime()
+		}
+	case nil:
+		t.null = true
+	default:
+		return fmt.Errorf("unsupported type: %T", value)
+	}
+	return nil
+}
+
+// GetValue returns the underlying time.Time value.
+// Returns zero time when unset or null.
+func (t TimestampTZ) GetValue() time.Time {
+	return t.value
+}
+
+// GetPtr returns a pointer to the time.Time value.
+// Returns nil when unset or null.
+func (t TimestampTZ) GetPtr() *time.Time {
+	if !t.set || t.null {
+		return nil
+	}
+	return &t.value
+}
+
+// IsSet indicates whether the value was explicitly set.
+// Returns false for uninitialized zero values.
+func (t TimestampTZ) IsSet() bool {
+	return t.set
+}
+
+// IsNull indicates whether the value was explicitly set to null.
+// Returns false for unset values.
+func (t TimestampTZ) IsNull() bool {
+	return t.null
+}
+
+// IsZero reports whether the value is unset.
+// Enables the `json:",omitzero"` tag option: unset fields are omitted
+// while explicit nulls are still encoded as null.
+func (t TimestampTZ) IsZero() bool {
+	return !t.set
+}
+
+// ToProto converts to protobuf Timestamp.
+// Returns nil when unset or null.
+func (t TimestampTZ) ToProto() *timestamppb.Timestamp {
+	if !t.set || t.null {
+		return nil
+	}
+	return timestamppb.New(t.value)
+}
+
+// ToPgx converts to pgtype.Timestamptz for PostgreSQL integration.
+// Sets Valid flag according to null/unset state.
+func (t TimestampTZ) ToPgx() pgtype.Timestamptz {
+	return pgtype.Timestamptz{
+		Time:  t.value,
+		Valid: t.set && !t.null,
+	}
+}
+
+// UTC returns a copy with the value converted to UTC.
+// Returns the value unchanged when unset or null.
+func (t TimestampTZ) UTC() TimestampTZ {
+	return t.In(time.UTC)
+}
+
+// In returns a copy with the value converted to the given location.
+// Returns the value unchanged when unset or null.
+func (t TimestampTZ) In(loc *time.Location) TimestampTZ {
+	if !t.set || t.null {
+		return t
+	}
+	return TimestampTZ{value: t.value.In(loc), set: true}
+}
+
+// ToTimestamp converts to zone-less Timestamp keeping the instant in UTC.
+func (t TimestampTZ) ToTimestamp() Timestamp {
+	if !t.set {
+		return Timestamp{}
+	}
+	if t.null {
+		return Timestamp{set: true, null: true}
+	}
+	return NewTimestamp(t.value.UTC())
+}
+
+// Unix returns the Unix timestamp (seconds since January 1, 1970 UTC).
+// Returns 0 when unset or null.
+func (t TimestampTZ) Unix() int64 {
+	if !t.set || t.null {
+		return 0
+	}
+	return t.value.Unix()
+}
+
+// Format returns a textual representation of the timestamp.
+// Returns empty string when unset or null.
+func (t TimestampTZ) Format(layout string) string {
+	if !t.set || t.null {
+		return ""
+	}
+	return t.value.Format(layout)
+}
+
+// RFC3339 returns the timestamp in RFC3339 format including the offset.
+// Returns empty string when unset or null.
+func (t TimestampTZ) RFC3339() string {
+	return t.Format(time.RFC3339)
+}
+
+// ScanTimestamptz implements pgtype.TimestamptzScanner so pgx can scan
+// timestamptz columns directly into TimestampTZ. SQL NULL is mapped to the explicit null state.
+func (t *TimestampTZ) ScanTimestamptz(v pgtype.Timestamptz) error {
+	return t.Set(v)
+}
+
+// TimestamptzValue implements pgtype.TimestamptzValuer so TimestampTZ can be
+// used as a query argument. Unset and null values are encoded as SQL NULL.
+func (t TimestampTZ) TimestamptzValue() (pgtype.Timestamptz, error) {
+	return t.ToPgx(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// SQL NULL is mapped to the explicit null state.
+func (t *TimestampTZ) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return t.Set(string(v))
+	case time.Time:
+		return t.Set(v.UTC())
+	default:
+		return t.Set(src)
+	}
+}
+
+// Value implements the database/sql/driver Valuer interface.
+// Returns nil (SQL NULL) when unset or null.
+func (t TimestampTZ) Value() (driver.Value, error) {
+	if !t.set || t.null {
+		return nil, nil
+	}
+	return t.value, nil
+}
+
+// MarshalGQL implements graphql.Marshaler for gqlgen.
+// Writes RFC3339 string with offset or null when unset or null.
+func (t TimestampTZ) MarshalGQL(w io.Writer) {
+	if !t.set || t.null {
+		graphql.Null.MarshalGQL(w)
+		return
+	}
+	graphql.MarshalString(t.value.Format(time.RFC3339Nano)).MarshalGQL(w)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler for gqlgen.
+// gqlgen calls it only for arguments present in the request, so an absent
+// argument stays unset while an explicit null sets the null state.
+// Accepts RFC3339 strings and Unix timestamps (seconds).
+func (t *TimestampTZ) UnmarshalGQL(v any) error {
+	switch v := v.(type) {
+	case nil, string:
+		return t.Set(v)
+	default:
+		unix, err := graphql.UnmarshalInt64(v)
+		if err != nil {
+			return fmt.Errorf("timestamp should be RFC3339 string or Unix timestamp: %w", err)
+		}
+		return t.Set(unix)
+	}
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Returns RFC3339 string with offset or null when unset or null.
+func (t TimestampTZ) MarshalJSON() ([]byte, error) {
+	if !t.set || t.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.value.Format(time.RFC3339Nano))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// A JSON null sets the explicit null state; a missing key never calls this
+// method and leaves the value unset (JSON Merge Patch semantics).
+// Accepts RFC3339 strings and Unix timestamps (seconds).
+func (t *TimestampTZ) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return t.Set(nil)
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		return t.Set(str)
+	}
+	var unix int64
+	if err := json.Unmarshal(data, &unix); err != nil {
+		return fmt.Errorf("timestamp should be RFC3339 string or Unix timestamp: %w", err)
+	}
+	return t.Set(unix)
+}